@@ -0,0 +1,99 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/crc32"
+	"io"
+	"log"
+
+	"github.com/spf13/afero"
+)
+
+// checksumRetries is how many times a block failing its checksum is transferred again
+const checksumRetries = 3
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// blockChecksum returns the big endian CRC32C of b, as carried by Read and Write
+func blockChecksum(b []byte) []byte {
+	sum := make([]byte, 4)
+	binary.BigEndian.PutUint32(sum, crc32.Checksum(b, castagnoli))
+	return sum
+}
+
+// retry calls fn again while it fails with ErrChecksumMismatch, up to checksumRetries times
+func retry(fn func() error) error {
+	err := fn()
+	for i := 0; i < checksumRetries && err == ErrChecksumMismatch; i++ {
+		err = fn()
+	}
+	return err
+}
+
+// verifyBlocks compares local with the remote file block by block and calls
+// fix for the blocks which differ, until both match or the retries are
+// exhausted. It does nothing if the server does not support checksums.
+func (c *fc) verifyBlocks(remote string, local afero.File, fix func(block int64) error) error {
+	if !c.supports(featureChecksum) {
+		return nil
+	}
+	for attempt := 0; ; attempt++ {
+		rsp, err := c.Checksum(remote, BlockSize)
+		if err != nil {
+			return err
+		}
+		bad, sum, err := diffBlocks(local, rsp.Blocks)
+		if err != nil {
+			return err
+		}
+		if len(bad) == 0 {
+			if sum != rsp.Checksum {
+				return ErrChecksumMismatch
+			}
+			return nil
+		}
+		if attempt == checksumRetries {
+			return ErrChecksumMismatch
+		}
+		for _, i := range bad {
+			log.Printf("Block %d of %s is corrupted, transferring it again", i, remote)
+			if err := fix(i); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// diffBlocks returns the blocks of local whose CRC32C differs from blocks,
+// and the SHA-256 of local
+func diffBlocks(local io.ReaderAt, blocks []uint32) ([]int64, string, error) {
+	hash := sha256.New()
+	buf := make([]byte, BlockSize)
+	var bad []int64
+	var i int64
+	for {
+		// afero in-memory files report reads past the end as unexpected EOF
+		n, err := local.ReadAt(buf, i*BlockSize)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, "", err
+		}
+		if n == 0 {
+			break
+		}
+		hash.Write(buf[:n])
+		if i >= int64(len(blocks)) || blocks[i] != crc32.Checksum(buf[:n], castagnoli) {
+			bad = append(bad, i)
+		}
+		i++
+		if n < len(buf) {
+			break
+		}
+	}
+	// the local file is shorter than the remote one
+	for ; i < int64(len(blocks)); i++ {
+		bad = append(bad, i)
+	}
+	return bad, hex.EncodeToString(hash.Sum(nil)), nil
+}
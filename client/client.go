@@ -1,11 +1,15 @@
 package client
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"fmt"
 	"io"
 	"log"
 	"os"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/micro/go-micro/client"
@@ -17,27 +21,61 @@ import (
 
 // FileClient is the client interface to access files
 type FileClient interface {
-	Open(filename string) (File, int64, error)
+	Open(filename string) (File, string, error)
+	OpenFile(filename string, flag int, perm os.FileMode) (File, string, error)
 
-	Read(sessionId int64, buf []byte) (int, error)
-	ReadAt(sessionId, offset, size int64) ([]byte, error)
-	GetBlock(sessionId, blockId int64) ([]byte, error)
+	Read(sessionId string, buf []byte) (int, error)
+	ReadAt(sessionId string, offset, size int64) ([]byte, error)
+	GetBlock(sessionId string, blockId int64) ([]byte, error)
 
 	Download(filename, saveFile string) error
 	DownloadAt(filename, saveFile string, blockId int) error
+	DownloadResume(filename, saveFile string) error
 
-	Create(filename string) (int64, error)
+	Create(filename string) (string, error)
+	CreateAtomic(filename string) (string, error)
 
-	Write(sessionID int64, buf []byte) (int, error)
-	WriteAt(sessionId, offset int64, buf []byte) (int, error)
-	SetBlock(sessionId, blockId int64, buf []byte) error
+	Write(sessionID string, buf []byte) (int, error)
+	WriteAt(sessionId string, offset int64, buf []byte) (int, error)
+	SetBlock(sessionId string, blockId int64, buf []byte) error
 
 	Upload(filename, saveFile string) error
 	UploadAt(filename, saveFile string, blockId int) error
+	UploadResume(filename, saveFile string) error
+
+	UploadBegin(filename string, size int64, mode os.FileMode) (string, error)
+	UploadAppend(uploadId string, offset int64, buf []byte) error
+	UploadStatus(uploadId string) (*proto.UploadStatusResponse, error)
+	UploadCommit(uploadId, checksum string) error
+	UploadAbort(uploadId string) error
+
+	UploadBeginPart(uploadId string, part int, size int64) (string, error)
+	UploadCommitPart(partId, checksum string) (string, error)
+	UploadCommitParts(uploadId string, parts []*proto.UploadPart) error
 
 	Stat(filename string) (*proto.StatResponse, error)
+	Checksum(filename string, blockSize int64) (*proto.ChecksumResponse, error)
+	List(dirname string, offset, limit int64, recursive bool) ([]os.FileInfo, bool, error)
+
+	Remove(filename string) error
+	RemoveAll(path string) error
+	Rename(oldname, newname string) error
+	Mkdir(name string, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+
+	Truncate(filename string, size int64) error
+	Chmod(filename string, mode os.FileMode) error
+	Chown(filename string, uid, gid int) error
+	Chtimes(filename string, atime, mtime time.Time) error
 
-	Close(sessionId int64) error
+	Ftruncate(sessionId string, size int64) error
+	Sync(sessionId string) error
+	Fchmod(sessionId string, mode os.FileMode) error
+	Fchown(sessionId string, uid, gid int) error
+	Fchtimes(sessionId string, atime, mtime time.Time) error
+
+	Close(sessionId string) error
+	Abort(sessionId string) error
 
 	WithContext(ctx context.Context) FileClient
 }
@@ -47,44 +85,117 @@ const (
 )
 
 type fc struct {
-	c   proto.FileService
-	os  afero.Fs
-	ctx context.Context
+	c        proto.FileService
+	os       afero.Fs
+	ctx      context.Context
+	features *features
+	opts     Options
 }
 
-func (c *fc) Open(filename string) (File, int64, error) {
+func (c *fc) Open(filename string) (File, string, error) {
 	s, err := c.Stat(filename)
 	if err != nil {
-		return nil, 0, err
+		return nil, "", err
 	}
 	rsp, err := c.c.Open(c.ctx, &proto.OpenRequest{Filename: filename})
 	if err != nil {
-		return nil, 0, err
+		return nil, "", osError(err)
 	}
 
-	f := &file{
+	return c.newFile(filename, rsp.Id, os.O_RDONLY, s), rsp.Id, nil
+}
+
+// newFile returns the File of the session id, opened with flag, described by s
+func (c *fc) newFile(filename, id string, flag int, s *proto.StatResponse) *file {
+	return &file{
 		name:         filename,
-		session:      rsp.Id,
-		offset:       0,
+		session:      id,
+		flag:         flag,
 		size:         s.Size,
-		lastModified: time.Unix(s.LastModified, 0),
+		lastModified: statTime(s),
+		mode:         statMode(s),
+		sys:          s,
 		c:            c,
+		ctx:          c.ctx,
 	}
-	return f, rsp.Id, nil
+}
+
+// openFlags maps the os package flags to the portable proto.OpenFlag bits
+var openFlags = []struct {
+	os    int
+	proto proto.OpenFlag
+}{
+	{os.O_WRONLY, proto.OpenFlag_O_WRONLY},
+	{os.O_RDWR, proto.OpenFlag_O_RDWR},
+	{os.O_APPEND, proto.OpenFlag_O_APPEND},
+	{os.O_CREATE, proto.OpenFlag_O_CREATE},
+	{os.O_EXCL, proto.OpenFlag_O_EXCL},
+	{os.O_SYNC, proto.OpenFlag_O_SYNC},
+	{os.O_TRUNC, proto.OpenFlag_O_TRUNC},
+}
+
+// OpenFile opens filename with the os package flag and perm, like os.OpenFile
+func (c *fc) OpenFile(filename string, flag int, perm os.FileMode) (File, string, error) {
+	var flags uint32
+	for _, v := range openFlags {
+		if flag&v.os != 0 {
+			flags |= uint32(v.proto)
+		}
+	}
+	rsp, err := c.c.OpenFile(c.ctx, &proto.OpenFileRequest{Filename: filename, Flags: flags, Perm: uint32(perm.Perm())})
+	if err != nil {
+		return nil, "", osError(err)
+	}
+	s, err := c.Stat(filename)
+	if err != nil {
+		c.Close(rsp.Id)
+		return nil, "", osError(err)
+	}
+
+	return c.newFile(filename, rsp.Id, flag, s), rsp.Id, nil
 }
 
 func (c *fc) Stat(filename string) (*proto.StatResponse, error) {
-	return c.c.Stat(c.ctx, &proto.StatRequest{Filename: filename})
+	rsp, err := c.c.Stat(c.ctx, &proto.StatRequest{Filename: filename})
+	if err != nil {
+		return nil, osError(err)
+	}
+	return rsp, nil
+}
+
+// Checksum returns the SHA-256 of filename and, when blockSize is positive,
+// the CRC32C of each of its blocks
+func (c *fc) Checksum(filename string, blockSize int64) (*proto.ChecksumResponse, error) {
+	rsp, err := c.c.Checksum(c.ctx, &proto.ChecksumRequest{Filename: filename, BlockSize: blockSize, Blocks: blockSize > 0})
+	if err != nil {
+		return nil, osError(err)
+	}
+	return rsp, nil
 }
 
-func (c *fc) GetBlock(sessionId, blockId int64) ([]byte, error) {
+func (c *fc) List(dirname string, offset, limit int64, recursive bool) ([]os.FileInfo, bool, error) {
+	rsp, err := c.c.List(c.ctx, &proto.ListRequest{Filename: dirname, Offset: offset, Limit: limit, Recursive: recursive})
+	if err != nil {
+		return nil, false, osError(err)
+	}
+	infos := make([]os.FileInfo, len(rsp.Files))
+	for i, v := range rsp.Files {
+		infos[i] = &fileInfo{v}
+	}
+	return infos, rsp.Eof, nil
+}
+
+func (c *fc) GetBlock(sessionId string, blockId int64) ([]byte, error) {
 	return c.ReadAt(sessionId, blockId*BlockSize, BlockSize)
 }
 
-func (c *fc) ReadAt(sessionId, offset, size int64) ([]byte, error) {
+func (c *fc) ReadAt(sessionId string, offset, size int64) ([]byte, error) {
 	rsp, err := c.c.Read(c.ctx, &proto.ReadRequest{Id: sessionId, Size: size, Offset: offset})
 	if err != nil {
-		return nil, err
+		return nil, osError(err)
+	}
+	if len(rsp.Checksum) > 0 && !bytes.Equal(rsp.Checksum, blockChecksum(rsp.Data)) {
+		return nil, ErrChecksumMismatch
 	}
 
 	if rsp.Eof {
@@ -102,7 +213,7 @@ func (c *fc) ReadAt(sessionId, offset, size int64) ([]byte, error) {
 	return rsp.Data, nil
 }
 
-func (c *fc) Read(sessionId int64, buf []byte) (int, error) {
+func (c *fc) Read(sessionId string, buf []byte) (int, error) {
 	b, err := c.ReadAt(sessionId, 0, int64(cap(buf)))
 	if err != nil {
 		return 0, err
@@ -111,22 +222,103 @@ func (c *fc) Read(sessionId int64, buf []byte) (int, error) {
 	return len(b), nil
 }
 
-func (c *fc) Close(sessionId int64) error {
+func (c *fc) Close(sessionId string) error {
 	_, err := c.c.Close(c.ctx, &proto.CloseRequest{Id: sessionId})
-	return err
+	return osError(err)
+}
+
+// Abort closes a session discarding what was written to it, if it was
+// created by CreateAtomic
+func (c *fc) Abort(sessionId string) error {
+	_, err := c.c.Close(c.ctx, &proto.CloseRequest{Id: sessionId, Abort: true})
+	return osError(err)
 }
 
+// Download copies filename to saveFile. A stream which does not match the
+// checksum of the file is downloaded again by blocks, which are verified
+// and retried one by one.
 func (c *fc) Download(filename, saveFile string) error {
+	if c.opts.workers <= 1 && c.supports(featureReadStream) {
+		err := c.downloadStream(filename, saveFile)
+		if err != ErrChecksumMismatch {
+			return err
+		}
+		log.Printf("Download %s: checksum mismatch, retrying by blocks", filename)
+	}
 	return c.DownloadAt(filename, saveFile, 0)
 }
 
+// downloadStream downloads filename in a single ReadStream call
+func (c *fc) downloadStream(filename, saveFile string) error {
+	stat, err := c.Stat(filename)
+	if err != nil {
+		return err
+	}
+	if stat.Type == "Directory" {
+		return &os.PathError{Op: "download", Path: filename, Err: syscall.EISDIR}
+	}
+	if c.os == nil {
+		return errors.New("Download cannot use a nil fs")
+	}
+	log.Printf("Download %s (%d bytes)\n", filename, stat.Size)
+
+	file, err := c.os.OpenFile(saveFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stream, err := c.c.ReadStream(c.ctx, &proto.ReadStreamRequest{Filename: filename, ChunkSize: BlockSize})
+	if err != nil {
+		return osError(err)
+	}
+	defer stream.Close()
+
+	hash := sha256.New()
+	var n int64
+	percent := 0
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return osError(err)
+		}
+		if _, err := file.WriteAt(rsp.Data, rsp.Offset); err != nil {
+			return err
+		}
+		hash.Write(rsp.Data)
+		n += int64(len(rsp.Data))
+		if stat.Size > 0 && int(n*100/stat.Size) != percent {
+			percent = int(n * 100 / stat.Size)
+			log.Printf("Downloading %s [%d%%]", filename, percent)
+		}
+		if rsp.Eof {
+			break
+		}
+	}
+	if c.supports(featureChecksum) {
+		sum, err := c.Checksum(filename, 0)
+		if err != nil {
+			return err
+		}
+		if sum.Size != n || sum.Checksum != hex.EncodeToString(hash.Sum(nil)) {
+			return ErrChecksumMismatch
+		}
+	}
+	log.Printf("Download %s completed", filename)
+
+	return nil
+}
+
 func (c *fc) DownloadAt(filename, saveFile string, blockId int) error {
 	stat, err := c.Stat(filename)
 	if err != nil {
 		return err
 	}
 	if stat.Type == "Directory" {
-		return errors.New(fmt.Sprintf("%s is directory.", filename))
+		return &os.PathError{Op: "download", Path: filename, Err: syscall.EISDIR}
 	}
 
 	blocks := int(stat.Size / BlockSize)
@@ -139,58 +331,153 @@ func (c *fc) DownloadAt(filename, saveFile string, blockId int) error {
 	fs := c.os
 	log.Printf("Download %s in %d blocks\n", filename, blocks-blockId)
 
-	file, err := fs.OpenFile(saveFile, os.O_CREATE|os.O_WRONLY, 0666)
+	f, err := fs.OpenFile(saveFile, os.O_CREATE|os.O_RDWR, 0666)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer f.Close()
+	// blocks are written out of order, size the file upfront
+	if err := f.Truncate(stat.Size); err != nil {
+		return err
+	}
+	file := &lockedFile{File: f}
 
 	_, sessionId, err := c.Open(filename)
 	if err != nil {
 		return err
 	}
+	defer c.Close(sessionId)
 
-	for i := blockId; i < blocks; i++ {
-		buf, rerr := c.GetBlock(sessionId, int64(i))
-		if rerr != nil && rerr != io.EOF {
-			return rerr
-		}
-		if _, werr := file.WriteAt(buf, int64(i)*BlockSize); werr != nil {
-			return werr
-		}
-
-		if i%((blocks-blockId)/100+1) == 0 {
-			log.Printf("Downloading %s [%d/%d] blocks", filename, i-blockId+1, blocks-blockId)
+	var done int64
+	err = c.transferBlocks(int64(blockId), int64(blocks), func(cl FileClient, i int64) error {
+		if err := downloadBlock(cl, sessionId, file, i); err != nil {
+			return err
 		}
-
-		if rerr == io.EOF {
-			break
+		if n := atomic.AddInt64(&done, 1); n%(int64(blocks-blockId)/100+1) == 0 {
+			log.Printf("Downloading %s [%d/%d] blocks", filename, n, blocks-blockId)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = c.verifyBlocks(filename, file, func(i int64) error {
+		return downloadBlock(c, sessionId, file, i)
+	})
+	if err != nil {
+		return err
 	}
 	log.Printf("Download %s completed", filename)
 
-	c.Close(sessionId)
-
 	return nil
 }
 
-func (c *fc) SetBlock(sessionId, blockId int64, buf []byte) error {
+func (c *fc) SetBlock(sessionId string, blockId int64, buf []byte) error {
 	_, err := c.WriteAt(sessionId, blockId*BlockSize, buf)
 	return err
 }
 
-func (c *fc) Create(filename string) (int64, error) {
+func (c *fc) Create(filename string) (string, error) {
 	rsp, err := c.c.Create(c.ctx, &proto.CreateRequest{Filename: filename})
 	if err != nil {
-		return 0, err
+		return "", osError(err)
 	}
 	return rsp.Id, nil
 }
 
+// CreateAtomic creates filename in a session whose content only replaces
+// filename when it is closed, readers never see a partially written file
+func (c *fc) CreateAtomic(filename string) (string, error) {
+	rsp, err := c.c.Create(c.ctx, &proto.CreateRequest{Filename: filename, Atomic: true})
+	if err != nil {
+		return "", osError(err)
+	}
+	return rsp.Id, nil
+}
+
+// Upload copies filename to saveFile. A stream which does not match the
+// checksum of the file is uploaded again by blocks, which are verified and
+// retried one by one.
 func (c *fc) Upload(filename, saveFile string) error {
+	if c.opts.workers <= 1 && c.supports(featureWriteStream) {
+		err := c.uploadStream(filename, saveFile)
+		if err != ErrChecksumMismatch {
+			return err
+		}
+		log.Printf("Upload %s: checksum mismatch, retrying by blocks", filename)
+	}
 	return c.UploadAt(filename, saveFile, 0)
 }
 
+// uploadStream uploads filename in a single WriteStream call
+func (c *fc) uploadStream(filename, saveFile string) error {
+	if c.os == nil {
+		return errors.New("Upload cannot use a nil fs")
+	}
+	stat, err := c.os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	if stat.IsDir() {
+		return &os.PathError{Op: "upload", Path: filename, Err: syscall.EISDIR}
+	}
+	f, err := c.os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stream, err := c.c.WriteStream(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	header := &proto.WriteStreamHeader{Filename: saveFile, Size: stat.Size(), Mode: uint32(stat.Mode().Perm())}
+	if err := stream.Send(&proto.WriteStreamRequest{Header: header}); err != nil {
+		return err
+	}
+	log.Printf("Upload %s (%d bytes)\n", filename, stat.Size())
+
+	hash := sha256.New()
+	buf := make([]byte, BlockSize)
+	var n int64
+	percent := 0
+	for {
+		r, err := f.Read(buf)
+		if r > 0 {
+			hash.Write(buf[:r])
+			if err := stream.Send(&proto.WriteStreamRequest{Data: buf[:r]}); err != nil {
+				return err
+			}
+			n += int64(r)
+			if stat.Size() > 0 && int(n*100/stat.Size()) != percent {
+				percent = int(n * 100 / stat.Size())
+				log.Printf("Uploading %s [%d%%]", filename, percent)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := stream.Send(&proto.WriteStreamRequest{Eof: true}); err != nil {
+		return err
+	}
+	rsp := &proto.WriteStreamResponse{}
+	if err := stream.RecvMsg(rsp); err != nil {
+		return osError(err)
+	}
+	if rsp.Size != n || rsp.Checksum != hex.EncodeToString(hash.Sum(nil)) {
+		return ErrChecksumMismatch
+	}
+	log.Printf("Upload %s completed", filename)
+
+	return nil
+}
+
 func (c *fc) UploadAt(filename, saveFile string, blockId int) error {
 	if c.os == nil {
 		return errors.New("UploadAt cannot use a nil fs")
@@ -201,69 +488,177 @@ func (c *fc) UploadAt(filename, saveFile string, blockId int) error {
 		return err
 	}
 	if stat.IsDir() {
-		return errors.New(fmt.Sprintf("%s is a directory", filename))
+		return &os.PathError{Op: "upload", Path: filename, Err: syscall.EISDIR}
+	}
+	var sessionId string
+	if blockId > 0 {
+		// a resumed upload keeps the blocks already sent
+		_, sessionId, err = c.OpenFile(saveFile, os.O_CREATE|os.O_WRONLY, 0666)
+	} else {
+		sessionId, err = c.Create(saveFile)
 	}
-	sessionId, err := c.Create(saveFile)
 	if err != nil {
 		return err
 	}
 	defer c.Close(sessionId)
+	// the blocks are written out of order, size the file upfront
+	if err := c.Ftruncate(sessionId, stat.Size()); err != nil {
+		return err
+	}
 	f, err := fs.Open(filename)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
+	file := &lockedFile{File: f}
 
 	blocks := int(stat.Size() / BlockSize)
 	if stat.Size()%BlockSize != 0 {
 		blocks += 1
 	}
-	for i := blockId; i < blocks; i++ {
-		buf := make([]byte, BlockSize)
-		n, err := f.ReadAt(buf, int64(i)*BlockSize)
-		if err != nil && err != io.EOF {
-			return err
-		}
-		b := make([]byte, n)
-		copy(b, buf)
-		if err := c.SetBlock(sessionId, int64(i), b); err != nil {
+	var done int64
+	err = c.transferBlocks(int64(blockId), int64(blocks), func(cl FileClient, i int64) error {
+		if err := uploadBlock(cl, sessionId, file, i); err != nil {
 			return err
 		}
-		if i%((blocks-blockId)/100+1) == 0 {
-			log.Printf("Uploading %s [%d/%d] blocks", filename, i-blockId+1, blocks-blockId)
-		}
-		if err == io.EOF {
-			break
+		if n := atomic.AddInt64(&done, 1); n%(int64(blocks-blockId)/100+1) == 0 {
+			log.Printf("Uploading %s [%d/%d] blocks", filename, n, blocks-blockId)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	log.Printf("Download %s completed", filename)
+	err = c.verifyBlocks(saveFile, file, func(i int64) error {
+		return uploadBlock(c, sessionId, file, i)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Upload %s completed", filename)
 	return nil
 }
 
-func (c *fc) Write(sessionID int64, buf []byte) (int, error) {
+func (c *fc) Write(sessionID string, buf []byte) (int, error) {
 	return c.WriteAt(sessionID, 0, buf)
 }
 
-func (c *fc) WriteAt(sessionId, offset int64, buf []byte) (int, error) {
-	rsp, err := c.c.Write(c.ctx, &proto.WriteRequest{Id: sessionId, Offset: offset, Data: buf})
+func (c *fc) WriteAt(sessionId string, offset int64, buf []byte) (int, error) {
+	rsp, err := c.c.Write(c.ctx, &proto.WriteRequest{Id: sessionId, Offset: offset, Data: buf, Checksum: blockChecksum(buf)})
 	if err != nil {
-		return 0, err
+		return 0, osError(err)
 	}
 	return int(rsp.Size), nil
 }
 
+func (c *fc) Remove(filename string) error {
+	if _, err := c.c.Remove(c.ctx, &proto.RemoveRequest{Filename: filename}); err != nil {
+		return &os.PathError{Op: "remove", Path: filename, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) RemoveAll(path string) error {
+	if _, err := c.c.RemoveAll(c.ctx, &proto.RemoveAllRequest{Filename: path}); err != nil {
+		return &os.PathError{Op: "removeall", Path: path, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) Rename(oldname, newname string) error {
+	if _, err := c.c.Rename(c.ctx, &proto.RenameRequest{Oldname: oldname, Newname: newname}); err != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) Mkdir(name string, perm os.FileMode) error {
+	if _, err := c.c.Mkdir(c.ctx, &proto.MkdirRequest{Filename: name, Perm: uint32(perm)}); err != nil {
+		return &os.PathError{Op: "mkdir", Path: name, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) MkdirAll(path string, perm os.FileMode) error {
+	if _, err := c.c.MkdirAll(c.ctx, &proto.MkdirAllRequest{Filename: path, Perm: uint32(perm)}); err != nil {
+		return &os.PathError{Op: "mkdir", Path: path, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) Truncate(filename string, size int64) error {
+	if _, err := c.c.Truncate(c.ctx, &proto.TruncateRequest{Filename: filename, Size: size}); err != nil {
+		return &os.PathError{Op: "truncate", Path: filename, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) Chmod(filename string, mode os.FileMode) error {
+	if _, err := c.c.Chmod(c.ctx, &proto.ChmodRequest{Filename: filename, Mode: uint32(mode)}); err != nil {
+		return &os.PathError{Op: "chmod", Path: filename, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) Chown(filename string, uid, gid int) error {
+	if _, err := c.c.Chown(c.ctx, &proto.ChownRequest{Filename: filename, Uid: int32(uid), Gid: int32(gid)}); err != nil {
+		return &os.PathError{Op: "chown", Path: filename, Err: osError(err)}
+	}
+	return nil
+}
+
+func (c *fc) Chtimes(filename string, atime, mtime time.Time) error {
+	if _, err := c.c.Chtimes(c.ctx, &proto.ChtimesRequest{Filename: filename, Atime: atime.UnixNano(), Mtime: mtime.UnixNano()}); err != nil {
+		return &os.PathError{Op: "chtimes", Path: filename, Err: osError(err)}
+	}
+	return nil
+}
+
+// Ftruncate changes the size of the file opened in the session
+func (c *fc) Ftruncate(sessionId string, size int64) error {
+	_, err := c.c.Truncate(c.ctx, &proto.TruncateRequest{Id: sessionId, Size: size})
+	return osError(err)
+}
+
+// Sync commits the content of the file opened in the session to stable storage
+func (c *fc) Sync(sessionId string) error {
+	_, err := c.c.Sync(c.ctx, &proto.SyncRequest{Id: sessionId})
+	return osError(err)
+}
+
+func (c *fc) Fchmod(sessionId string, mode os.FileMode) error {
+	_, err := c.c.Chmod(c.ctx, &proto.ChmodRequest{Id: sessionId, Mode: uint32(mode)})
+	return osError(err)
+}
+
+func (c *fc) Fchown(sessionId string, uid, gid int) error {
+	_, err := c.c.Chown(c.ctx, &proto.ChownRequest{Id: sessionId, Uid: int32(uid), Gid: int32(gid)})
+	return osError(err)
+}
+
+func (c *fc) Fchtimes(sessionId string, atime, mtime time.Time) error {
+	_, err := c.c.Chtimes(c.ctx, &proto.ChtimesRequest{Id: sessionId, Atime: atime.UnixNano(), Mtime: mtime.UnixNano()})
+	return osError(err)
+}
+
 func (c *fc) WithContext(ctx context.Context) FileClient {
 	if ctx == nil {
 		ctx = context.TODO()
 	}
 	return &fc{
-		c:   c.c,
-		os:  c.os,
-		ctx: ctx,
+		c:        c.c,
+		os:       c.os,
+		ctx:      ctx,
+		features: c.features,
+		opts:     c.opts,
 	}
 }
 
 // NewClient returns a new FileClient which uses a micro FileClient
-func NewClient(service string, c client.Client, fs afero.Fs) FileClient {
-	return &fc{proto.NewFileService(service, c), fs, context.TODO()}
+func NewClient(service string, c client.Client, fs afero.Fs, options ...Option) FileClient {
+	var o Options
+	for _, opt := range options {
+		opt(&o)
+	}
+	return &fc{proto.NewFileService(service, c), fs, context.TODO(), &features{}, o}
 }
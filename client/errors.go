@@ -0,0 +1,62 @@
+package client
+
+import (
+	"net/http"
+	"os"
+	"syscall"
+
+	"github.com/micro/go-micro/errors"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// codeErrors are the errors matching the proto.ErrorCode carried in the
+// status of the File service errors, the other codes are returned as is
+// to keep their detail
+var codeErrors = map[proto.ErrorCode]error{
+	proto.ErrorCode_NOT_FOUND:         os.ErrNotExist,
+	proto.ErrorCode_EXISTS:            os.ErrExist,
+	proto.ErrorCode_PERMISSION_DENIED: os.ErrPermission,
+	proto.ErrorCode_IS_DIRECTORY:      syscall.EISDIR,
+	proto.ErrorCode_NOT_DIRECTORY:     syscall.ENOTDIR,
+	proto.ErrorCode_NOT_EMPTY:         syscall.ENOTEMPTY,
+	proto.ErrorCode_SESSION_NOT_FOUND: ErrSessionNotFound,
+	proto.ErrorCode_SESSION_EXPIRED:   ErrSessionExpired,
+	proto.ErrorCode_QUOTA_EXCEEDED:    ErrQuotaExceeded,
+	proto.ErrorCode_CHECKSUM_MISMATCH: ErrChecksumMismatch,
+	proto.ErrorCode_NOT_SUPPORTED:     ErrNotSupported,
+	proto.ErrorCode_UNAUTHENTICATED:   ErrUnauthenticated,
+}
+
+// osError converts an error returned by the File service into the matching
+// os package error, so that os.IsNotExist and friends work on remote errors
+func osError(err error) error {
+	e, ok := err.(*errors.Error)
+	if !ok {
+		return err
+	}
+	if code, ok := proto.ErrorCode_value[e.Status]; ok {
+		if err, ok := codeErrors[proto.ErrorCode(code)]; ok {
+			return err
+		}
+		return e
+	}
+	// servers predating the error codes only set the http code
+	switch e.Code {
+	case http.StatusNotFound:
+		return os.ErrNotExist
+	case http.StatusConflict:
+		return os.ErrExist
+	case http.StatusUnauthorized:
+		return ErrUnauthenticated
+	case http.StatusForbidden:
+		return os.ErrPermission
+	case http.StatusGone:
+		return ErrSessionExpired
+	case http.StatusUnprocessableEntity:
+		return ErrChecksumMismatch
+	case http.StatusNotImplemented:
+		return ErrNotSupported
+	}
+	return err
+}
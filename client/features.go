@@ -0,0 +1,38 @@
+package client
+
+import (
+	"sync"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+const (
+	featureReadStream  = "read_stream"
+	featureWriteStream = "write_stream"
+	featureChecksum    = "checksum"
+	featureUpload      = "upload_session"
+)
+
+// features caches the optional capabilities advertised by the server
+type features struct {
+	sync.Mutex
+	set map[string]bool
+}
+
+// supports reports whether the server advertises feature. Servers which do
+// not implement Features are considered to support none of them.
+func (c *fc) supports(feature string) bool {
+	c.features.Lock()
+	defer c.features.Unlock()
+	if c.features.set == nil {
+		rsp, err := c.c.Features(c.ctx, &proto.FeaturesRequest{})
+		if err != nil {
+			return false
+		}
+		c.features.set = make(map[string]bool)
+		for _, v := range rsp.Features {
+			c.features.set[v] = true
+		}
+	}
+	return c.features.set[feature]
+}
@@ -5,12 +5,33 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
+
+	proto "github.com/partitio/go-file/proto"
 )
 
 var (
-	ErrFileClosed = errors.New("File is closed")
+	ErrFileClosed     = errors.New("File is closed")
+	ErrNotSupported   = errors.New("operation not supported")
+	ErrSessionExpired = errors.New("session expired")
+
+	// ErrSessionNotFound is returned when the session or upload is not
+	// known by the server, it was closed or never opened
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrQuotaExceeded is returned when the server is out of sessions or
+	// out of space
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	errWriteAtInAppendMode = errors.New("invalid use of WriteAt on file opened with O_APPEND")
+
+	// ErrChecksumMismatch is returned when transferred data does not match
+	// its checksum, after the transfer has been retried
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrUnauthenticated is returned when the server does not accept the
+	// credentials of the caller
+	ErrUnauthenticated = errors.New("unauthenticated")
 )
 
 type File interface {
@@ -26,6 +47,9 @@ type File interface {
 	Stat() (os.FileInfo, error)
 	Sync() error
 	Truncate(size int64) error
+	Chmod(mode os.FileMode) error
+	Chown(uid, gid int) error
+	Chtimes(atime, mtime time.Time) error
 	WriteString(s string) (ret int, err error)
 
 	os.FileInfo
@@ -35,10 +59,14 @@ type File interface {
 
 type file struct {
 	name         string
-	session      int64
+	session      string
+	flag         int
 	offset       int64
 	size         int64
 	lastModified time.Time
+	mode         os.FileMode
+	sys          *proto.StatResponse
+	dirOffset    int64
 	c            FileClient
 	closed       bool
 	mu           sync.RWMutex
@@ -48,30 +76,21 @@ type file struct {
 func (f *file) Read(p []byte) (n int, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	if f.closed == true {
-		return 0, ErrFileClosed
-	}
-	b, err := f.c.WithContext(f.ctx).ReadAt(f.session, f.offset, int64(len(p)))
-	if err != nil {
-		return 0, err
-	}
-	copy(p, b)
-	f.offset += int64(len(b))
-	return len(b), nil
+	n, err = f.readAt(p, f.offset)
+	f.offset += int64(n)
+	return n, err
 }
 
 func (f *file) Write(p []byte) (n int, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	if f.closed == true {
-		return 0, ErrFileClosed
+	// the server writes at the end of files opened in append mode
+	if f.flag&os.O_APPEND != 0 {
+		f.offset = f.size
 	}
-	b, err := f.c.WithContext(f.ctx).WriteAt(f.session, f.offset, p)
-	if err != nil {
-		return 0, err
-	}
-	f.offset += int64(b)
-	return b, nil
+	n, err = f.writeAt(p, f.offset)
+	f.offset += int64(n)
+	return n, err
 }
 
 func (f *file) Seek(offset int64, whence int) (int64, error) {
@@ -80,56 +99,187 @@ func (f *file) Seek(offset int64, whence int) (int64, error) {
 	if f.closed == true {
 		return 0, ErrFileClosed
 	}
-	if f.closed == true {
-		return 0, ErrFileClosed
-	}
 	switch whence {
-	case 0:
-		f.offset = offset
-	case 1:
-		f.offset += offset
-	case 2:
-		f.offset = f.size + offset
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, &os.PathError{Op: "seek", Path: f.name, Err: os.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &os.PathError{Op: "seek", Path: f.name, Err: os.ErrInvalid}
 	}
+	f.offset = offset
 	return f.offset, nil
 }
 
 func (f *file) ReadAt(p []byte, off int64) (n int, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	f.offset = off
-	return f.Read(p)
+	n, err = f.readAt(p, off)
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
 }
 
 func (f *file) WriteAt(p []byte, off int64) (n int, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	f.offset = off
-	return f.Write(p)
+	if f.flag&os.O_APPEND != 0 {
+		return 0, errWriteAtInAppendMode
+	}
+	return f.writeAt(p, off)
+}
+
+// readAt reads from the remote file without moving the offset, f.mu must be held
+func (f *file) readAt(p []byte, off int64) (int, error) {
+	if f.closed == true {
+		return 0, ErrFileClosed
+	}
+	b, err := f.client().ReadAt(f.session, off, int64(len(p)))
+	return copy(p, b), err
+}
+
+// writeAt writes to the remote file without moving the offset, f.mu must be held
+func (f *file) writeAt(p []byte, off int64) (int, error) {
+	if f.closed == true {
+		return 0, ErrFileClosed
+	}
+	n, err := f.client().WriteAt(f.session, off, p)
+	if end := off + int64(n); end > f.size {
+		f.size = end
+	}
+	return n, err
+}
+
+// client returns the FileClient bound to the file context, if any
+func (f *file) client() FileClient {
+	if f.ctx == nil {
+		return f.c
+	}
+	return f.c.WithContext(f.ctx)
 }
 
 func (f *file) Name() string {
-	panic("implement me")
+	return f.name
 }
 
-func (f *file) Readdir(_ int) ([]os.FileInfo, error) {
-	return nil, &os.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a dir")}
+func (f *file) Readdir(count int) ([]os.FileInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed == true {
+		return nil, ErrFileClosed
+	}
+	if !f.mode.IsDir() {
+		return nil, &os.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a dir")}
+	}
+	limit := int64(count)
+	if count <= 0 {
+		limit = 0
+	}
+	infos, eof, err := f.client().List(f.name, f.dirOffset, limit, false)
+	if err != nil {
+		return nil, err
+	}
+	f.dirOffset += int64(len(infos))
+	if count > 0 && len(infos) == 0 && eof {
+		return nil, io.EOF
+	}
+	return infos, nil
 }
 
 func (f *file) Readdirnames(n int) ([]string, error) {
-	return nil, &os.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a dir")}
+	infos, err := f.Readdir(n)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(infos))
+	for i, v := range infos {
+		names[i] = v.Name()
+	}
+	return names, nil
 }
 
+// Stat returns a snapshot of the file information, updated with the changes
+// made through f
 func (f *file) Stat() (os.FileInfo, error) {
-	return f, nil
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	s := &proto.StatResponse{}
+	if f.sys != nil {
+		*s = *f.sys
+	}
+	s.Size = f.size
+	s.Mode = uint32(f.mode)
+	s.LastModified = f.lastModified.Unix()
+	s.ModTime = f.lastModified.UnixNano()
+	return &statInfo{name: filepath.Base(f.name), stat: s}, nil
 }
 
 func (f *file) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed == true {
+		return ErrFileClosed
+	}
+	if err := f.client().Sync(f.session); err != nil {
+		return &os.PathError{Op: "sync", Path: f.name, Err: err}
+	}
 	return nil
 }
 
 func (f *file) Truncate(size int64) error {
-	return errors.New("operation not supported")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed == true {
+		return ErrFileClosed
+	}
+	if err := f.client().Ftruncate(f.session, size); err != nil {
+		return &os.PathError{Op: "truncate", Path: f.name, Err: err}
+	}
+	f.size = size
+	return nil
+}
+
+func (f *file) Chmod(mode os.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed == true {
+		return ErrFileClosed
+	}
+	if err := f.client().Fchmod(f.session, mode); err != nil {
+		return &os.PathError{Op: "chmod", Path: f.name, Err: err}
+	}
+	f.mode = f.mode&^(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky) | mode&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)
+	return nil
+}
+
+func (f *file) Chown(uid, gid int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed == true {
+		return ErrFileClosed
+	}
+	if err := f.client().Fchown(f.session, uid, gid); err != nil {
+		return &os.PathError{Op: "chown", Path: f.name, Err: err}
+	}
+	return nil
+}
+
+func (f *file) Chtimes(atime, mtime time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed == true {
+		return ErrFileClosed
+	}
+	if err := f.client().Fchtimes(f.session, atime, mtime); err != nil {
+		return &os.PathError{Op: "chtimes", Path: f.name, Err: err}
+	}
+	f.lastModified = mtime
+	return nil
 }
 
 func (f *file) WriteString(s string) (ret int, err error) {
@@ -143,7 +293,9 @@ func (f *file) Size() int64 {
 }
 
 func (f *file) Mode() os.FileMode {
-	return os.ModePerm
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.mode
 }
 
 func (f *file) ModTime() time.Time {
@@ -153,18 +305,27 @@ func (f *file) ModTime() time.Time {
 }
 
 func (f *file) IsDir() bool {
-	return false
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.mode.IsDir()
 }
 
+// Sys returns the *proto.StatResponse the file was opened with, if any
 func (f *file) Sys() interface{} {
-	return nil
+	if f.sys == nil {
+		return nil
+	}
+	return f.sys
 }
 
 func (f *file) Close() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	if f.closed == true {
+		return ErrFileClosed
+	}
 	f.closed = true
-	return f.c.WithContext(f.ctx).Close(f.session)
+	return f.client().Close(f.session)
 }
 
 func (f *file) WithContext(ctx context.Context) File {
@@ -175,10 +336,14 @@ func (f *file) WithContext(ctx context.Context) File {
 	}
 	return &file{
 		name:         f.name,
-		session:      f.size,
+		session:      f.session,
+		flag:         f.flag,
 		offset:       f.offset,
 		size:         f.size,
 		lastModified: f.lastModified,
+		mode:         f.mode,
+		sys:          f.sys,
+		dirOffset:    f.dirOffset,
 		c:            f.c,
 		closed:       f.closed,
 		ctx:          ctx,
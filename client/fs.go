@@ -0,0 +1,108 @@
+package client
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+var _ afero.Fs = (*RemoteFs)(nil)
+
+// RemoteFs is an afero.Fs operating on the files served by a File service.
+// The served directory is the root of the filesystem: names are cleaned and
+// made relative to it, like afero.BasePathFs does.
+type RemoteFs struct {
+	c FileClient
+}
+
+// NewRemoteFs returns an afero.Fs using c to access the remote files
+func NewRemoteFs(c FileClient) *RemoteFs {
+	return &RemoteFs{c: c}
+}
+
+func (r *RemoteFs) Create(name string) (afero.File, error) {
+	id, err := r.c.Create(remotePath(name))
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: osError(err)}
+	}
+	return &file{
+		name:         remotePath(name),
+		session:      id,
+		flag:         os.O_RDWR | os.O_CREATE | os.O_TRUNC,
+		lastModified: time.Now(),
+		mode:         0666,
+		c:            r.c,
+	}, nil
+}
+
+func (r *RemoteFs) Mkdir(name string, perm os.FileMode) error {
+	return r.c.Mkdir(remotePath(name), perm)
+}
+
+func (r *RemoteFs) MkdirAll(path string, perm os.FileMode) error {
+	return r.c.MkdirAll(remotePath(path), perm)
+}
+
+func (r *RemoteFs) Open(name string) (afero.File, error) {
+	f, _, err := r.c.Open(remotePath(name))
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: osError(err)}
+	}
+	return f, nil
+}
+
+func (r *RemoteFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) == 0 {
+		return r.Open(name)
+	}
+	f, _, err := r.c.OpenFile(remotePath(name), flag, perm)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: osError(err)}
+	}
+	return f, nil
+}
+
+func (r *RemoteFs) Remove(name string) error {
+	return r.c.Remove(remotePath(name))
+}
+
+func (r *RemoteFs) RemoveAll(path string) error {
+	return r.c.RemoveAll(remotePath(path))
+}
+
+func (r *RemoteFs) Rename(oldname, newname string) error {
+	return r.c.Rename(remotePath(oldname), remotePath(newname))
+}
+
+func (r *RemoteFs) Stat(name string) (os.FileInfo, error) {
+	s, err := r.c.Stat(remotePath(name))
+	if err != nil {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: osError(err)}
+	}
+	return &statInfo{name: filepath.Base(name), stat: s}, nil
+}
+
+func (r *RemoteFs) Name() string {
+	return "RemoteFs"
+}
+
+func (r *RemoteFs) Chmod(name string, mode os.FileMode) error {
+	return r.c.Chmod(remotePath(name), mode)
+}
+
+func (r *RemoteFs) Chown(name string, uid, gid int) error {
+	return r.c.Chown(remotePath(name), uid, gid)
+}
+
+func (r *RemoteFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return r.c.Chtimes(remotePath(name), atime, mtime)
+}
+
+// remotePath returns name relative to the root of the served directory
+func remotePath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+}
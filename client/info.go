@@ -0,0 +1,92 @@
+package client
+
+import (
+	"os"
+	"time"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// fileInfo implements os.FileInfo for the entries returned by List
+type fileInfo struct {
+	info *proto.FileInfo
+}
+
+func (i *fileInfo) Name() string {
+	return i.info.Name
+}
+
+func (i *fileInfo) Size() int64 {
+	return i.info.Size
+}
+
+func (i *fileInfo) Mode() os.FileMode {
+	return os.FileMode(i.info.Mode)
+}
+
+func (i *fileInfo) ModTime() time.Time {
+	if i.info.ModTime != 0 {
+		return time.Unix(0, i.info.ModTime)
+	}
+	return time.Unix(i.info.LastModified, 0)
+}
+
+func (i *fileInfo) IsDir() bool {
+	return i.Mode().IsDir()
+}
+
+func (i *fileInfo) Sys() interface{} {
+	return i.info
+}
+
+// statInfo implements os.FileInfo for a Stat response, Sys returns the
+// *proto.StatResponse which holds the owner and identity of the file
+type statInfo struct {
+	name string
+	stat *proto.StatResponse
+}
+
+func (i *statInfo) Name() string {
+	return i.name
+}
+
+func (i *statInfo) Size() int64 {
+	return i.stat.Size
+}
+
+func (i *statInfo) Mode() os.FileMode {
+	return statMode(i.stat)
+}
+
+func (i *statInfo) ModTime() time.Time {
+	return statTime(i.stat)
+}
+
+func (i *statInfo) IsDir() bool {
+	return i.Mode().IsDir()
+}
+
+func (i *statInfo) Sys() interface{} {
+	return i.stat
+}
+
+// statMode returns the mode of s, the servers which do not report it only
+// tell files from directories
+func statMode(s *proto.StatResponse) os.FileMode {
+	if s.Mode != 0 {
+		return os.FileMode(s.Mode)
+	}
+	if s.Type == "Directory" {
+		return os.ModeDir | os.ModePerm
+	}
+	return os.ModePerm
+}
+
+// statTime returns the modification time of s, with a nanosecond resolution
+// when the server reports it
+func statTime(s *proto.StatResponse) time.Time {
+	if s.ModTime != 0 {
+		return time.Unix(0, s.ModTime)
+	}
+	return time.Unix(s.LastModified, 0)
+}
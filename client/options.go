@@ -0,0 +1,25 @@
+package client
+
+type Option func(o *Options)
+
+type Options struct {
+	workers       int
+	inflightBytes int64
+}
+
+// WithConcurrency sets the number of blocks transferred in parallel by
+// DownloadAt and UploadAt. Download and Upload use the block transfer
+// instead of a stream when it is greater than one.
+func WithConcurrency(workers int) Option {
+	return func(o *Options) {
+		o.workers = workers
+	}
+}
+
+// WithInflightBytes limits the amount of block data being transferred at the
+// same time, it is rounded down to a whole number of blocks
+func WithInflightBytes(n int64) Option {
+	return func(o *Options) {
+		o.inflightBytes = n
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"io"
+	"os"
+)
+
+// blockReader is an io.ReadSeeker reading a remote file by whole blocks, so
+// that small reads and the seeks between the parts of a multi-range http
+// request do not each cost a round trip
+type blockReader struct {
+	r      io.ReaderAt
+	size   int64
+	offset int64
+	// buf holds the block starting at start
+	buf   []byte
+	start int64
+}
+
+// NewBlockReader returns an io.ReadSeeker over the size bytes of r, which is
+// read BlockSize bytes at a time
+func NewBlockReader(r io.ReaderAt, size int64) io.ReadSeeker {
+	return &blockReader{r: r, size: size, start: -1}
+}
+
+func (b *blockReader) Read(p []byte) (int, error) {
+	if b.offset >= b.size {
+		return 0, io.EOF
+	}
+	if b.start < 0 || b.offset < b.start || b.offset >= b.start+int64(len(b.buf)) {
+		if err := b.fill(b.offset - b.offset%BlockSize); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, b.buf[b.offset-b.start:])
+	b.offset += int64(n)
+	return n, nil
+}
+
+// fill reads the block starting at off
+func (b *blockReader) fill(off int64) error {
+	if b.buf == nil {
+		b.buf = make([]byte, BlockSize)
+	}
+	n, err := b.r.ReadAt(b.buf[:cap(b.buf)], off)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if n == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	b.buf, b.start = b.buf[:n], off
+	return nil
+}
+
+func (b *blockReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.size
+	default:
+		return 0, os.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	b.offset = offset
+	return offset, nil
+}
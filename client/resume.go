@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// resumeSuffix is appended to the local file name to store the state of a
+// resumable download
+const resumeSuffix = ".resume"
+
+// resumeState identifies the version of the remote file a partial download
+// belongs to
+type resumeState struct {
+	Size         int64 `json:"size"`
+	LastModified int64 `json:"last_modified"`
+}
+
+func readResumeState(fs afero.Fs, name string) (*resumeState, error) {
+	b, err := afero.ReadFile(fs, name)
+	if err != nil {
+		return nil, err
+	}
+	s := &resumeState{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// resumeBlock returns the first block of saveFile which is missing or does
+// not match the remote file. Blocks can only be trusted when the server
+// supports checksums, otherwise the download restarts from the beginning.
+func (c *fc) resumeBlock(filename, saveFile string) (int, error) {
+	if !c.supports(featureChecksum) {
+		return 0, nil
+	}
+	f, err := c.os.Open(saveFile)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	sum, err := c.Checksum(filename, BlockSize)
+	if err != nil {
+		return 0, err
+	}
+	bad, _, err := diffBlocks(f, sum.Blocks)
+	if err != nil {
+		return 0, err
+	}
+	if len(bad) == 0 {
+		return len(sum.Blocks), nil
+	}
+	return int(bad[0]), nil
+}
+
+// DownloadResume downloads filename to saveFile, continuing a previous
+// interrupted download from the first missing or corrupted block. The
+// download restarts from scratch if the remote file changed meanwhile.
+func (c *fc) DownloadResume(filename, saveFile string) error {
+	if c.os == nil {
+		return errors.New("DownloadResume cannot use a nil fs")
+	}
+	stat, err := c.Stat(filename)
+	if err != nil {
+		return err
+	}
+	state := &resumeState{Size: stat.Size, LastModified: statTime(stat).UnixNano()}
+	statePath := saveFile + resumeSuffix
+
+	if old, err := readResumeState(c.os, statePath); err == nil && *old != *state {
+		log.Printf("%s changed since the download started, restarting it", filename)
+		if err := c.os.Remove(saveFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(c.os, statePath, b, 0666); err != nil {
+		return err
+	}
+
+	blockId, err := c.resumeBlock(filename, saveFile)
+	if err != nil {
+		return err
+	}
+	if blockId > 0 {
+		log.Printf("Resume download of %s at block %d", filename, blockId)
+	}
+	if err := c.DownloadAt(filename, saveFile, blockId); err != nil {
+		return err
+	}
+	return c.os.Remove(statePath)
+}
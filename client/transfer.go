@@ -0,0 +1,120 @@
+package client
+
+import (
+	"io"
+	"sync"
+
+	"github.com/spf13/afero"
+	"golang.org/x/net/context"
+)
+
+// lockedFile serializes the positional reads and writes on a local file,
+// afero in-memory files are not safe for concurrent ReadAt and WriteAt
+type lockedFile struct {
+	afero.File
+	mu sync.Mutex
+}
+
+func (f *lockedFile) ReadAt(b []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.ReadAt(b, off)
+}
+
+func (f *lockedFile) WriteAt(b []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.WriteAt(b, off)
+}
+
+// downloadBlock copies the block i of the session into file
+func downloadBlock(c FileClient, sessionId string, file io.WriterAt, i int64) error {
+	return retry(func() error {
+		buf, err := c.GetBlock(sessionId, i)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		_, err = file.WriteAt(buf, i*BlockSize)
+		return err
+	})
+}
+
+// uploadBlock copies the block i of file into the session
+func uploadBlock(c FileClient, sessionId string, file io.ReaderAt, i int64) error {
+	buf := make([]byte, BlockSize)
+	n, err := file.ReadAt(buf, i*BlockSize)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return retry(func() error {
+		return c.SetBlock(sessionId, i, buf[:n])
+	})
+}
+
+// transferBlocks calls fn for the blocks [from, to), using up to the
+// configured number of workers. The first failure cancels the remaining
+// transfers and the error of the lowest failing block is returned.
+func (c *fc) transferBlocks(from, to int64, fn func(c FileClient, block int64) error) error {
+	workers := c.opts.workers
+	if workers < 1 {
+		workers = 1
+	}
+	if budget := c.opts.inflightBytes / BlockSize; c.opts.inflightBytes > 0 && budget < int64(workers) {
+		workers = int(budget)
+		if workers < 1 {
+			workers = 1
+		}
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	cl := c.WithContext(ctx)
+
+	blocks := make(chan int64)
+	go func() {
+		defer close(blocks)
+		for i := from; i < to; i++ {
+			select {
+			case blocks <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu       sync.Mutex
+		failed   = int64(-1)
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range blocks {
+				if ctx.Err() != nil {
+					return
+				}
+				err := fn(cl, i)
+				if err == nil {
+					continue
+				}
+				// failures seen after the cancellation are caused by it
+				genuine := ctx.Err() == nil
+				mu.Lock()
+				if failed < 0 || genuine && i < failed {
+					failed, firstErr = i, err
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	// the workers stop without an error when the caller context is done
+	if firstErr == nil && c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+	return firstErr
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/spf13/afero"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// uploadSuffix is appended to the local file name to store the upload
+// session of a resumable upload
+const uploadSuffix = ".upload"
+
+// uploadState identifies the upload session of a local file, the version
+// of the file it was started with and its destination
+type uploadState struct {
+	Id           string `json:"id"`
+	SaveFile     string `json:"save_file"`
+	Size         int64  `json:"size"`
+	LastModified int64  `json:"last_modified"`
+}
+
+// UploadBegin starts an upload session of size bytes to filename, the file
+// is only written when the session is committed
+func (c *fc) UploadBegin(filename string, size int64, mode os.FileMode) (string, error) {
+	rsp, err := c.c.UploadBegin(c.ctx, &proto.UploadBeginRequest{Filename: filename, Size: size, Mode: uint32(mode.Perm())})
+	if err != nil {
+		return "", osError(err)
+	}
+	return rsp.Id, nil
+}
+
+func (c *fc) UploadAppend(uploadId string, offset int64, buf []byte) error {
+	_, err := c.c.UploadAppend(c.ctx, &proto.UploadAppendRequest{Id: uploadId, Offset: offset, Data: buf, Checksum: blockChecksum(buf)})
+	return osError(err)
+}
+
+// UploadStatus returns the ranges of the upload session received so far
+func (c *fc) UploadStatus(uploadId string) (*proto.UploadStatusResponse, error) {
+	rsp, err := c.c.UploadStatus(c.ctx, &proto.UploadStatusRequest{Id: uploadId})
+	if err != nil {
+		return nil, osError(err)
+	}
+	return rsp, nil
+}
+
+// UploadCommit publishes the uploaded file, checksum is its optional SHA-256
+func (c *fc) UploadCommit(uploadId, checksum string) error {
+	_, err := c.c.UploadCommit(c.ctx, &proto.UploadCommitRequest{Id: uploadId, Checksum: checksum})
+	return osError(err)
+}
+
+func (c *fc) UploadAbort(uploadId string) error {
+	_, err := c.c.UploadAbort(c.ctx, &proto.UploadAbortRequest{Id: uploadId})
+	return osError(err)
+}
+
+// UploadBeginPart starts the upload of size bytes of the part number part of
+// the multipart upload uploadId, it replaces the previous upload of the part
+func (c *fc) UploadBeginPart(uploadId string, part int, size int64) (string, error) {
+	rsp, err := c.c.UploadBegin(c.ctx, &proto.UploadBeginRequest{Parent: uploadId, Part: int32(part), Size: size})
+	if err != nil {
+		return "", osError(err)
+	}
+	return rsp.Id, nil
+}
+
+// UploadCommitPart seals a complete part, checksum is its optional SHA-256,
+// and returns its MD5
+func (c *fc) UploadCommitPart(partId, checksum string) (string, error) {
+	rsp, err := c.c.UploadCommit(c.ctx, &proto.UploadCommitRequest{Id: partId, Checksum: checksum})
+	if err != nil {
+		return "", osError(err)
+	}
+	return rsp.Md5, nil
+}
+
+// UploadCommitParts publishes the multipart upload uploadId as the
+// concatenation of parts, given with the MD5 returned by UploadCommitPart
+func (c *fc) UploadCommitParts(uploadId string, parts []*proto.UploadPart) error {
+	_, err := c.c.UploadCommit(c.ctx, &proto.UploadCommitRequest{Id: uploadId, Parts: parts})
+	return osError(err)
+}
+
+// received reports whether the block i of a file of size bytes is covered by ranges
+func received(ranges []*proto.Range, i, size int64) bool {
+	start, end := i*BlockSize, (i+1)*BlockSize
+	if end > size {
+		end = size
+	}
+	for _, r := range ranges {
+		if r.Offset <= start && r.Offset+r.Length >= end {
+			return true
+		}
+	}
+	return false
+}
+
+// resumeUpload returns the upload session of a previous interrupted upload
+// of the local file to the same destination, if it is still valid
+func (c *fc) resumeUpload(statePath string, state *uploadState) (*proto.UploadStatusResponse, bool) {
+	b, err := afero.ReadFile(c.os, statePath)
+	if err != nil {
+		return nil, false
+	}
+	old := &uploadState{}
+	if err := json.Unmarshal(b, old); err != nil || old.SaveFile != state.SaveFile || old.Size != state.Size || old.LastModified != state.LastModified {
+		return nil, false
+	}
+	status, err := c.UploadStatus(old.Id)
+	if err != nil || status.Filename != state.SaveFile {
+		return nil, false
+	}
+	state.Id = old.Id
+	return status, true
+}
+
+// UploadResume uploads filename to saveFile through an upload session, so
+// that an interrupted upload only sends the missing blocks when it is run
+// again. The session is kept in a file next to filename until it is
+// committed. It falls back to UploadAt on servers without upload sessions.
+func (c *fc) UploadResume(filename, saveFile string) error {
+	if c.os == nil {
+		return errors.New("UploadResume cannot use a nil fs")
+	}
+	if !c.supports(featureUpload) {
+		return c.UploadAt(filename, saveFile, 0)
+	}
+	stat, err := c.os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	if stat.IsDir() {
+		return &os.PathError{Op: "upload", Path: filename, Err: syscall.EISDIR}
+	}
+	f, err := c.os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	file := &lockedFile{File: f}
+
+	statePath := filename + uploadSuffix
+	state := &uploadState{SaveFile: saveFile, Size: stat.Size(), LastModified: stat.ModTime().UnixNano()}
+	status, ok := c.resumeUpload(statePath, state)
+	if ok {
+		log.Printf("Resume upload of %s", filename)
+	} else {
+		if state.Id, err = c.UploadBegin(saveFile, stat.Size(), stat.Mode()); err != nil {
+			return err
+		}
+		b, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		if err := afero.WriteFile(c.os, statePath, b, 0666); err != nil {
+			return err
+		}
+		status = &proto.UploadStatusResponse{}
+	}
+
+	blocks := stat.Size() / BlockSize
+	if stat.Size()%BlockSize != 0 {
+		blocks += 1
+	}
+	var done int64
+	err = c.transferBlocks(0, blocks, func(cl FileClient, i int64) error {
+		if received(status.Ranges, i, stat.Size()) {
+			return nil
+		}
+		buf := make([]byte, BlockSize)
+		n, err := file.ReadAt(buf, i*BlockSize)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err := retry(func() error { return cl.UploadAppend(state.Id, i*BlockSize, buf[:n]) }); err != nil {
+			return err
+		}
+		if n := atomic.AddInt64(&done, 1); n%(blocks/100+1) == 0 {
+			log.Printf("Uploading %s [%d/%d] blocks", filename, n, blocks)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(file, 0, stat.Size())); err != nil {
+		return err
+	}
+	if err := c.UploadCommit(state.Id, hex.EncodeToString(hash.Sum(nil))); err != nil {
+		return err
+	}
+	log.Printf("Upload %s completed", filename)
+
+	return c.os.Remove(statePath)
+}
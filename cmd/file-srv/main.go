@@ -2,10 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/micro/go-micro"
 	mclient "github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/metadata"
 	"github.com/micro/go-micro/registry/memory"
 	"github.com/micro/go-micro/web"
 	"github.com/sirupsen/logrus"
@@ -13,10 +21,23 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/partitio/go-file"
+	"github.com/partitio/go-file/handler"
+	"github.com/partitio/go-file/http_handler"
+	"github.com/partitio/go-file/s3_handler"
+	"github.com/partitio/go-file/webdav_handler"
 )
 
 var fsName string
 var cacheDuration time.Duration
+var idleTimeout time.Duration
+var uploadTTL time.Duration
+var maxSessions int
+var maxUploadSize int64
+var webdav bool
+var s3Address, s3AccessKey, s3SecretKey string
+var signingKey string
+var authTokens []string
+var jwtSecret, jwtPublicKey, gatewayToken string
 var fsFlagName = "fs"
 func main() {
 	// service cancellation context
@@ -42,8 +63,26 @@ func main() {
 				}),
 			)
 			fs := getFileSystem(fsName)
+			opts := []handler.Option{
+				handler.WithContext(ctx),
+				handler.WithIdleTimeout(idleTimeout),
+				handler.WithUploadTTL(uploadTTL),
+				handler.WithMaxSessions(maxSessions),
+			}
+			authorizer, err := getAuthorizer()
+			if err != nil {
+				return err
+			}
+			// the s3 requests are made with the gateway token, anonymous ones
+			// would get its access to the files
+			if s3Address != "" && s3AccessKey == "" && (authorizer != nil || gatewayToken != "") {
+				return fmt.Errorf("the s3 gateway needs --s3-access-key when the File service requires authentication")
+			}
+			if authorizer != nil {
+				opts = append(opts, handler.WithAuthorizer(authorizer))
+			}
 			// register file handler
-			if err := file.RegisterFileHandler(s.Server(), args[0], fs); err != nil {
+			if err := file.RegisterFileHandler(s.Server(), args[0], fs, opts...); err != nil {
 				return err
 			}
 
@@ -59,10 +98,45 @@ func main() {
 
 			// new file client
 			mc := mclient.NewClient(mclient.Registry(r), mclient.RequestTimeout(24 * time.Hour))
-			wh := file.NewHttpHandler("go.micro.srv.file", mc, nil)
+			// the callers of the http and webdav handlers authenticate themselves,
+			// the signed urls and the s3 requests use the gateway token
+			forward := http_handler.ForwardHeaders("Authorization")
+			gateway := func(h http.Header) context.Context {
+				if gatewayToken == "" {
+					return context.Background()
+				}
+				return metadata.NewContext(context.Background(), metadata.Metadata{"Authorization": "Bearer " + gatewayToken})
+			}
+			wh := file.NewHttpHandler("go.micro.srv.file", mc, nil, http_handler.WithHeaderMatcher(forward), http_handler.WithMaxUploadSize(maxUploadSize), http_handler.WithPrefix("/uploads"), http_handler.WithTusPrefix("/files"))
 			w := web.NewService(web.Address(":18888"), web.Context(ctx))
 			w.Handle("/uploads", wh)
 			w.Handle("/uploads/", wh)
+			w.Handle("/files", wh)
+			w.Handle("/files/", wh)
+			if signingKey != "" {
+				sh := http_handler.NewSignedHandler([]byte(signingKey), file.NewHttpHandler("go.micro.srv.file", mc, nil, http_handler.WithHeaderMatcher(gateway), http_handler.WithMaxUploadSize(maxUploadSize), http_handler.WithPrefix("/shared")))
+				w.Handle("/shared", sh)
+				w.Handle("/shared/", sh)
+			}
+			if webdav {
+				dh := file.NewWebDAVHandler("go.micro.srv.file", mc, webdav_handler.WithHeaderMatcher(webdav_handler.HeaderMatcher(forward)), webdav_handler.WithPrefix("/webdav"))
+				w.Handle("/webdav", dh)
+				w.Handle("/webdav/", dh)
+			}
+
+			if s3Address != "" {
+				opts := []s3_handler.Option{s3_handler.WithHeaderMatcher(gateway)}
+				if s3AccessKey != "" {
+					opts = append(opts, s3_handler.WithCredentials(s3AccessKey, s3SecretKey))
+				}
+				s3 := web.NewService(web.Address(s3Address), web.Context(ctx))
+				s3.Handle("/", file.NewS3Handler("go.micro.srv.file", mc, opts...))
+				go func() {
+					if err := s3.Run(); err != nil {
+						logrus.Fatal(err)
+					}
+				}()
+			}
 
 			if err := w.Run(); err != nil {
 				return err
@@ -72,9 +146,60 @@ func main() {
 	}
 	cmd.Flags().StringVar(&fsName,fsFlagName, "os", "Filesystem that should be used by the handler (os/memory/cache)")
 	cmd.Flags().DurationVar(&cacheDuration, "cache", 5 * time.Second, "Duration of cache used if cache is selected as filesystem")
+	cmd.Flags().DurationVar(&idleTimeout, "idle-timeout", 10*time.Minute, "Duration after which idle file sessions are closed (0 to disable)")
+	cmd.Flags().DurationVar(&uploadTTL, "upload-ttl", 24*time.Hour, "Duration after which unused upload sessions are removed (0 to disable)")
+	cmd.Flags().IntVar(&maxSessions, "max-sessions", 0, "Maximum number of file sessions open at the same time (0 for unlimited)")
+	cmd.Flags().Int64Var(&maxUploadSize, "max-upload-size", 0, "Maximum size in bytes of an http upload (0 for unlimited)")
+	cmd.Flags().StringVar(&signingKey, "signing-key", "", "Key of the signed urls served on /shared (disabled when empty)")
+	cmd.Flags().BoolVar(&webdav, "webdav", false, "Serve the files over WebDAV on /webdav, so that they can be mounted as a network drive")
+	cmd.Flags().StringVar(&s3Address, "s3", "", "Address of an S3 compatible gateway to the files (disabled when empty)")
+	cmd.Flags().StringVar(&s3AccessKey, "s3-access-key", "", "Access key of the S3 gateway requests (no authentication when empty)")
+	cmd.Flags().StringVar(&s3SecretKey, "s3-secret-key", "", "Secret key of the S3 gateway requests")
+	cmd.Flags().StringArrayVar(&authTokens, "auth-token", nil, "Bearer token accepted by the File service, as subject:token (repeatable)")
+	cmd.Flags().StringVar(&jwtSecret, "jwt-secret", "", "Secret of the HS256 JSON Web Tokens accepted by the File service")
+	cmd.Flags().StringVar(&jwtPublicKey, "jwt-public-key", "", "PEM file of the RSA public key of the RS256 JSON Web Tokens accepted by the File service")
+	cmd.Flags().StringVar(&gatewayToken, "gateway-token", "", "Bearer token of the signed url and S3 gateways requests to the File service")
 	cmd.Execute()
 }
 
+// getAuthorizer returns the Authorizer of the flags, nil when the File
+// service accepts any caller
+func getAuthorizer() (handler.Authorizer, error) {
+	switch {
+	case len(authTokens) > 0:
+		tokens := make(map[string]string)
+		for _, v := range authTokens {
+			kv := strings.SplitN(v, ":", 2)
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				return nil, fmt.Errorf("invalid token %q, expected subject:token", v)
+			}
+			tokens[kv[1]] = kv[0]
+		}
+		return handler.NewTokenAuthorizer(tokens), nil
+	case jwtSecret != "":
+		return handler.NewHS256Authorizer([]byte(jwtSecret)), nil
+	case jwtPublicKey != "":
+		b, err := ioutil.ReadFile(jwtPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(b)
+		if block == nil {
+			return nil, fmt.Errorf("%s is not a PEM file", jwtPublicKey)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an RSA public key", jwtPublicKey)
+		}
+		return handler.NewRS256Authorizer(rsaKey), nil
+	}
+	return nil, nil
+}
+
 func getFileSystem(fs string) afero.Fs {
 	switch fs {
 	case "cache":
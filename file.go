@@ -10,16 +10,30 @@ import (
 	"github.com/partitio/go-file/client"
 	"github.com/partitio/go-file/handler"
 	"github.com/partitio/go-file/http_handler"
+	"github.com/partitio/go-file/s3_handler"
+	"github.com/partitio/go-file/webdav_handler"
 )
 
-func RegisterFileHandler(server server.Server, dir string, fs afero.Fs) error {
-	return handler.RegisterHandler(server, dir, fs)
+func RegisterFileHandler(server server.Server, dir string, fs afero.Fs, options ...handler.Option) error {
+	return handler.RegisterHandler(server, dir, fs, options...)
 }
 
-func NewClient(service string, c mclient.Client, fs afero.Fs) client.FileClient {
-	return client.NewClient(service, c, fs)
+func NewClient(service string, c mclient.Client, fs afero.Fs, options ...client.Option) client.FileClient {
+	return client.NewClient(service, c, fs, options...)
+}
+
+func NewRemoteFs(service string, c mclient.Client) afero.Fs {
+	return client.NewRemoteFs(client.NewClient(service, c, nil))
 }
 
 func NewHttpHandler(service string, c mclient.Client, fs afero.Fs, options ...http_handler.Option) http.Handler {
 	return http_handler.NewFileHandler(client.NewClient(service, c, fs), options...)
 }
+
+func NewWebDAVHandler(service string, c mclient.Client, options ...webdav_handler.Option) http.Handler {
+	return webdav_handler.NewWebDAVHandler(client.NewClient(service, c, nil), options...)
+}
+
+func NewS3Handler(service string, c mclient.Client, options ...s3_handler.Option) http.Handler {
+	return s3_handler.NewS3Handler(client.NewClient(service, c, nil), options...)
+}
@@ -1,17 +1,44 @@
 package file
 
 import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/micro/go-micro"
+	mclient "github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/metadata"
 	"github.com/micro/go-micro/registry/memory"
+	"github.com/minio/minio-go/pkg/s3signer"
 	"github.com/spf13/afero"
 	"golang.org/x/net/context"
 
 	"github.com/partitio/go-file/client"
 	"github.com/partitio/go-file/handler"
+	"github.com/partitio/go-file/http_handler"
 	proto "github.com/partitio/go-file/proto"
+	"github.com/partitio/go-file/s3_handler"
+	"github.com/partitio/go-file/webdav_handler"
 )
 
 func TestFileServer(t *testing.T) {
@@ -88,3 +115,1157 @@ func TestFileServer(t *testing.T) {
 		return
 	}
 }
+
+// startServer runs a File service serving dir of fs until the returned
+// function is called
+func startServer(t *testing.T, fs afero.Fs, dir string, options ...handler.Option) (mclient.Client, context.CancelFunc) {
+	// service cancellation context
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// wait chan
+	wait := make(chan bool)
+	r := memory.NewRegistry()
+	// make service
+	s := micro.NewService(
+		micro.Name("go.micro.srv.file"),
+		micro.Registry(r),
+		micro.Context(ctx),
+		micro.AfterStart(func() error {
+			close(wait)
+			return nil
+		}),
+	)
+	h, err := handler.NewHandler(dir, fs, options...)
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	// register file handler
+	if err := proto.RegisterFileHandler(s.Server(), h); err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+
+	// start service
+	go s.Run()
+
+	// wait for start
+	<-wait
+
+	return s.Client(), cancel
+}
+
+func TestFileServerLarge(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := make([]byte, 3*client.BlockSize+client.BlockSize/2)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/local/large.file", data, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, fs)
+
+	if err := cl.Upload("/local/large.file", "large.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/large.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("uploaded file differs (%v)", err)
+	}
+	if err := cl.Download("large.file", "/local/downloaded.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/local/downloaded.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("downloaded file differs (%v)", err)
+	}
+
+	pl := client.NewClient("go.micro.srv.file", c, fs, client.WithConcurrency(3), client.WithInflightBytes(2*client.BlockSize))
+	if err := pl.Upload("/local/large.file", "parallel.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/parallel.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("parallel uploaded file differs (%v)", err)
+	}
+	if err := pl.Download("parallel.file", "/local/parallel.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/local/parallel.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("parallel downloaded file differs (%v)", err)
+	}
+}
+
+func TestFileServerChecksum(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := make([]byte, 2*client.BlockSize+100)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/srv/data.file", data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, fs)
+
+	sum, err := cl.Checksum("data.file", client.BlockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := sha256.Sum256(data); sum.Size != int64(len(data)) || sum.Checksum != hex.EncodeToString(h[:]) || len(sum.Blocks) != 3 {
+		t.Fatalf("unexpected checksum %v", sum)
+	}
+
+	// the blocks before the resumed one are corrupted locally and must be fixed
+	corrupted := append([]byte{}, data...)
+	corrupted[10] ^= 0xff
+	if err := afero.WriteFile(fs, "/local/data.file", corrupted[:client.BlockSize+10], 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.DownloadAt("data.file", "/local/data.file", 1); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/local/data.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("downloaded file differs (%v)", err)
+	}
+
+	// a resumed upload keeps the blocks sent and cuts a longer file
+	if err := afero.WriteFile(fs, "/srv/up.file", append(data[:client.BlockSize:client.BlockSize], make([]byte, 3*client.BlockSize)...), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.UploadAt("/local/data.file", "up.file", 1); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/up.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("resumed upload differs (%v)", err)
+	}
+}
+
+func TestFileServerResume(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := make([]byte, 3*client.BlockSize+100)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/srv/data.file", data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, fs)
+
+	// an interrupted download with a corrupted second block
+	partial := append([]byte{}, data[:2*client.BlockSize]...)
+	partial[client.BlockSize+1] ^= 0xff
+	if err := afero.WriteFile(fs, "/local/data.file", partial, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.DownloadResume("data.file", "/local/data.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/local/data.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("resumed file differs (%v)", err)
+	}
+	if _, err := fs.Stat("/local/data.file.resume"); !os.IsNotExist(err) {
+		t.Fatalf("resume state not removed (%v)", err)
+	}
+
+	// the remote file changed since the partial download started
+	if err := afero.WriteFile(fs, "/local/data.file.resume", []byte(`{"size":1,"last_modified":1}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/local/data.file", []byte("stale content"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.DownloadResume("data.file", "/local/data.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/local/data.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("restarted file differs (%v)", err)
+	}
+}
+
+func TestFileServerUploadResume(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := make([]byte, 3*client.BlockSize+100)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/local/data.file", data, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, fs, client.WithConcurrency(2))
+
+	// an upload interrupted after its second block
+	id, err := cl.UploadBegin("data.file", int64(len(data)), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.UploadAppend(id, client.BlockSize, data[client.BlockSize:2*client.BlockSize]); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := fs.Stat("/local/data.file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := fmt.Sprintf(`{"id":%q,"save_file":"data.file","size":%d,"last_modified":%d}`, id, fi.Size(), fi.ModTime().UnixNano())
+	if err := afero.WriteFile(fs, "/local/data.file.upload", []byte(state), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Stat("/srv/data.file"); !os.IsNotExist(err) {
+		t.Fatal("incomplete upload published")
+	}
+
+	// the session is not resumed by an upload to another destination
+	if err := cl.UploadResume("/local/data.file", "other.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/other.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("uploaded file differs (%v)", err)
+	}
+	if _, err := fs.Stat("/srv/data.file"); !os.IsNotExist(err) {
+		t.Fatal("upload resumed to another destination")
+	}
+	if err := afero.WriteFile(fs, "/local/data.file.upload", []byte(state), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cl.UploadResume("/local/data.file", "data.file"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/data.file"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("uploaded file differs (%v)", err)
+	}
+	if _, err := fs.Stat("/local/data.file.upload"); !os.IsNotExist(err) {
+		t.Fatalf("upload state not removed (%v)", err)
+	}
+	if status, err := cl.UploadStatus(id); err != nil || len(status.Ranges) != 1 || status.Ranges[0].Length != int64(len(data)) {
+		t.Fatalf("upload session not committed (%v)", err)
+	}
+}
+
+func TestFileServerList(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, v := range []string{"/srv/a.txt", "/srv/b.txt", "/srv/sub/c.txt"} {
+		if err := afero.WriteFile(fs, v, []byte(v), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, fs)
+
+	d, _, err := cl.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if !d.IsDir() {
+		t.Fatal("expected a directory")
+	}
+
+	var names []string
+	for {
+		infos, err := d.Readdir(2)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range infos {
+			names = append(names, v.Name())
+		}
+	}
+	if want := []string{"a.txt", "b.txt", "sub"}; !equal(names, want) {
+		t.Errorf("got %v, expected %v", names, want)
+	}
+
+	infos, eof, err := cl.List("", 0, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eof {
+		t.Error("expected eof")
+	}
+	names = names[:0]
+	for _, v := range infos {
+		names = append(names, v.Name())
+	}
+	sort.Strings(names)
+	if want := []string{"a.txt", "b.txt", "sub", "sub/c.txt"}; !equal(names, want) {
+		t.Errorf("got %v, expected %v", names, want)
+	}
+
+	if _, _, err := cl.List("a.txt", 0, 0, false); err == nil {
+		t.Error("expected an error listing a file")
+	}
+}
+
+func TestFileServerManage(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, fs)
+
+	if err := cl.MkdirAll("a/b", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Mkdir("a", 0755); !os.IsExist(err) {
+		t.Errorf("got %v, expected an exist error", err)
+	}
+	if err := afero.WriteFile(fs, "/srv/a/b/c.txt", []byte("c"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Rename("a/b/c.txt", "a/c.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := afero.Exists(fs, "/srv/a/c.txt"); !ok {
+		t.Error("expected renamed file to exist")
+	}
+	if err := cl.Remove("a/b/c.txt"); !os.IsNotExist(err) {
+		t.Errorf("got %v, expected a not exist error", err)
+	}
+	if err := cl.Remove(""); !os.IsPermission(err) {
+		t.Errorf("got %v, expected a permission error", err)
+	}
+	if err := cl.RemoveAll("a"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := afero.Exists(fs, "/srv/a"); ok {
+		t.Error("expected directory to be removed")
+	}
+}
+
+func TestRemoteFs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/dir/hello.txt", []byte("hello world"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	rfs := client.NewRemoteFs(client.NewClient("go.micro.srv.file", c, nil))
+
+	if err := afero.WriteFile(rfs, "/dir/new.txt", []byte("new file"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/new.txt"); err != nil || string(b) != "new file" {
+		t.Errorf("got %q (%v), expected 'new file'", b, err)
+	}
+
+	if _, err := rfs.Stat("/missing"); !os.IsNotExist(err) {
+		t.Errorf("got %v, expected a not exist error", err)
+	}
+	if _, err := rfs.Open("/missing"); !os.IsNotExist(err) {
+		t.Errorf("got %v, expected a not exist error", err)
+	}
+	cl := client.NewClient("go.micro.srv.file", c, nil)
+	if _, _, err := cl.List("dir/new.txt", 0, 0, false); !errors.Is(err, syscall.ENOTDIR) {
+		t.Errorf("got %v, expected a not a directory error", err)
+	}
+	if _, err := cl.Create("../escape.txt"); !os.IsPermission(err) {
+		t.Errorf("got %v, expected a permission error", err)
+	}
+	if _, err := cl.ReadAt("unknown", 0, 1); err != client.ErrSessionNotFound {
+		t.Errorf("got %v, expected a session not found error", err)
+	}
+	if ok, err := afero.DirExists(rfs, "/dir"); !ok || err != nil {
+		t.Errorf("expected /dir to exist (%v)", err)
+	}
+	infos, err := afero.ReadDir(rfs, "/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].Name() != "hello.txt" || infos[1].Name() != "new.txt" {
+		t.Errorf("unexpected listing %v", infos)
+	}
+
+	f, err := rfs.OpenFile("/dir/hello.txt", os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("!"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("x"), 0); err == nil {
+		t.Error("expected WriteAt to fail in append mode")
+	}
+	if _, err := f.Read(make([]byte, 1)); !os.IsPermission(err) {
+		t.Errorf("got %v, expected a permission error", err)
+	}
+	f.Close()
+	if b, err := afero.ReadFile(fs, "/srv/dir/hello.txt"); err != nil || string(b) != "hello world!" {
+		t.Errorf("got %q (%v), expected 'hello world!'", b, err)
+	}
+	if _, err := rfs.OpenFile("/dir/hello.txt", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666); !os.IsExist(err) {
+		t.Errorf("got %v, expected an exist error", err)
+	}
+	f, err = rfs.OpenFile("/dir/hello.txt", os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("H"), 0); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	cfs := afero.NewCacheOnReadFs(rfs, afero.NewMemMapFs(), time.Minute)
+	b, err := afero.ReadFile(cfs, "/dir/hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "Hello world!" {
+		t.Errorf("got %s, expected 'Hello world!'", string(b))
+	}
+}
+
+func TestRemoteFsAttributes(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/file.txt", []byte("hello world"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	rfs := client.NewRemoteFs(client.NewClient("go.micro.srv.file", c, nil))
+
+	if err := rfs.Chmod("/file.txt", 0600); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := rfs.Chtimes("/file.txt", mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := fs.Stat("/srv/file.txt"); err != nil || fi.Mode().Perm() != 0600 || !fi.ModTime().Equal(mtime) {
+		t.Errorf("unexpected mode or time (%v)", err)
+	}
+	if fi, err := rfs.Stat("/file.txt"); err != nil || fi.Mode() != 0600 || !fi.ModTime().Equal(mtime) || fi.IsDir() {
+		t.Errorf("unexpected remote stat %v (%v)", fi, err)
+	}
+	if fi, err := rfs.Stat("/"); err != nil || !fi.IsDir() {
+		t.Errorf("expected a directory (%v)", err)
+	}
+	if err, ok := rfs.Chown("/file.txt", 0, 0).(*os.PathError); !ok || err.Err != client.ErrNotSupported {
+		t.Errorf("got %v, expected chown not to be supported", err)
+	}
+
+	f, err := rfs.OpenFile("/file.txt", os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Truncate(5); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if b, err := afero.ReadFile(fs, "/srv/file.txt"); err != nil || string(b) != "hello" {
+		t.Errorf("got %q (%v), expected 'hello'", b, err)
+	}
+
+	f, err = rfs.Open("/file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(0); !os.IsPermission(err) {
+		t.Errorf("got %v, expected a permission error", err)
+	}
+}
+
+func TestHTTPDownload(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := make([]byte, client.BlockSize+100)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/srv/data.file", data, 0666); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := fs.Chtimes("/srv/data.file", mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	h := http_handler.NewFileHandler(client.NewClient("go.micro.srv.file", c, nil))
+	get := func(header ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/data.file", nil)
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get()
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), data) {
+		t.Fatalf("got %d, expected the file", w.Code)
+	}
+	// the checksum of a large file is only computed for a conditional request
+	if w.Header().Get("Etag") != fmt.Sprintf(`W/"%x-%x"`, len(data), mtime.UnixNano()) || w.Header().Get("Last-Modified") != mtime.Format(http.TimeFormat) {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+
+	if w := get("If-None-Match", etag); w.Code != http.StatusNotModified {
+		t.Errorf("got %d, expected not modified", w.Code)
+	}
+	if w := get(); w.Header().Get("Etag") != etag {
+		t.Errorf("got %q, expected the cached strong etag", w.Header().Get("Etag"))
+	}
+	if w := get("If-Modified-Since", mtime.Format(http.TimeFormat)); w.Code != http.StatusNotModified {
+		t.Errorf("got %d, expected not modified", w.Code)
+	}
+	if w := get("Range", "bytes=0-9", "If-Range", `"stale"`); w.Code != http.StatusOK || w.Body.Len() != len(data) {
+		t.Errorf("got %d, expected the whole file for a stale If-Range", w.Code)
+	}
+
+	start := int64(client.BlockSize - 5)
+	w = get("Range", fmt.Sprintf("bytes=0-9,%d-%d", start, start+9), "If-Range", etag)
+	if w.Code != http.StatusPartialContent {
+		t.Fatalf("got %d, expected partial content", w.Code)
+	}
+	_, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr := multipart.NewReader(w.Body, params["boundary"])
+	for _, off := range []int64{0, start} {
+		p, err := mr.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err := ioutil.ReadAll(p); err != nil || !bytes.Equal(b, data[off:off+10]) {
+			t.Errorf("unexpected part at %d (%v)", off, err)
+		}
+	}
+}
+
+func TestHTTPUpload(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/small.file", []byte("small"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	h := http_handler.NewFileHandler(client.NewClient("go.micro.srv.file", c, nil), http_handler.WithMaxUploadSize(2*client.BlockSize))
+	data := make([]byte, client.BlockSize+100)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	mw.WriteField("comment", "ignored")
+	fw, err := mw.CreateFormFile("file", "form.file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(data)
+	mw.Close()
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/form.file"); err != nil || !bytes.Equal(b, data) {
+		t.Errorf("form upload differs (%v)", err)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/put.file", bytes.NewReader(data)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/put.file"); err != nil || !bytes.Equal(b, data) {
+		t.Errorf("put upload differs (%v)", err)
+	}
+
+	// the size is checked while streaming when the length is not known
+	r = httptest.NewRequest(http.MethodPut, "/small.file", bytes.NewReader(make([]byte, 3*client.BlockSize)))
+	r.ContentLength = -1
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got %d, expected request entity too large", w.Code)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/small.file"); err != nil || string(b) != "small" {
+		t.Errorf("got %q (%v), expected the file to be left untouched", b, err)
+	}
+}
+
+func TestHTTPPaths(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/etc/passwd", []byte("root"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	h := http_handler.NewFileHandler(client.NewClient("go.micro.srv.file", c, nil), http_handler.WithPrefix("/files"))
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := serve(httptest.NewRequest(http.MethodPut, "/files/a/b/c.txt?v=1", strings.NewReader("nested"))); w.Code != http.StatusOK {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/a/b/c.txt"); err != nil || string(b) != "nested" {
+		t.Errorf("got %q (%v), expected 'nested'", b, err)
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, "/files/a/b/c.txt?v=1", nil)); w.Code != http.StatusOK || w.Body.String() != "nested" {
+		t.Errorf("got %d %q, expected 'nested'", w.Code, w.Body)
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	fw, err := mw.CreateFormFile("file", `C:\Users\me\d.txt`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte("form"))
+	mw.Close()
+	r := httptest.NewRequest(http.MethodPost, "/files/a", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	if w := serve(r); w.Code != http.StatusOK {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/a/d.txt"); err != nil || string(b) != "form" {
+		t.Errorf("got %q (%v), expected 'form'", b, err)
+	}
+
+	for _, p := range []string{"/files/../../etc/passwd", "/files/a/../../../etc/passwd", "/other/a/b/c.txt", "/filesa/b/c.txt"} {
+		if w := serve(httptest.NewRequest(http.MethodGet, p, nil)); w.Code != http.StatusNotFound {
+			t.Errorf("%s: got %d, expected not found", p, w.Code)
+		}
+	}
+}
+
+func TestTus(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	newHandler := func() http.Handler {
+		return http_handler.NewFileHandler(client.NewClient("go.micro.srv.file", c, nil), http_handler.WithTusPrefix("/files"), http_handler.WithMaxUploadSize(1<<20))
+	}
+	h := newHandler()
+	serve := func(method, target string, body []byte, headers ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, bytes.NewReader(body))
+		r.Header.Set("Tus-Resumable", "1.0.0")
+		for i := 0; i < len(headers); i += 2 {
+			r.Header.Set(headers[i], headers[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	patch := func(location string, offset int, data []byte, headers ...string) *httptest.ResponseRecorder {
+		return serve(http.MethodPatch, location, data, append([]string{"Content-Type", "application/offset+octet-stream", "Upload-Offset", fmt.Sprint(offset)}, headers...)...)
+	}
+	metadata := "filename " + base64.StdEncoding.EncodeToString([]byte("dir/big.bin"))
+
+	if w := serve(http.MethodOptions, "/files", nil); w.Code != http.StatusNoContent || w.Header().Get("Tus-Extension") != "creation,termination,checksum" {
+		t.Errorf("options: got %d %v", w.Code, w.Header())
+	}
+	r := httptest.NewRequest(http.MethodPost, "/files", nil)
+	r.Header.Set("Upload-Length", "10")
+	w := httptest.NewRecorder()
+	if h.ServeHTTP(w, r); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("missing Tus-Resumable: got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/files", nil, "Upload-Length", "2000000", "Upload-Metadata", metadata); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("upload too large: got %d", w.Code)
+	}
+
+	data := make([]byte, client.BlockSize+100)
+	rand.Read(data)
+	w = serve(http.MethodPost, "/files", nil, "Upload-Length", fmt.Sprint(len(data)), "Upload-Metadata", metadata)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: got %d %s", w.Code, w.Body)
+	}
+	location := w.Header().Get("Location")
+	if !strings.HasPrefix(location, "/files/") {
+		t.Fatalf("unexpected location %q", location)
+	}
+	if w := serve(http.MethodHead, location, nil); w.Code != http.StatusOK || w.Header().Get("Upload-Offset") != "0" || w.Header().Get("Upload-Length") != fmt.Sprint(len(data)) {
+		t.Errorf("head: got %d %v", w.Code, w.Header())
+	}
+	if w := serve(http.MethodPatch, location, data[:10], "Upload-Offset", "0"); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("patch without content type: got %d", w.Code)
+	}
+
+	split := client.BlockSize + 10
+	if w := patch(location, 0, data[:split]); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != fmt.Sprint(split) {
+		t.Fatalf("patch: got %d %s", w.Code, w.Body)
+	}
+	// the offsets are kept by the File service
+	h = newHandler()
+	if w := serve(http.MethodHead, location, nil); w.Header().Get("Upload-Offset") != fmt.Sprint(split) {
+		t.Errorf("got offset %s after a restart, expected %d", w.Header().Get("Upload-Offset"), split)
+	}
+	if w := patch(location, 0, data[:split]); w.Code != http.StatusConflict {
+		t.Errorf("patch at a stale offset: got %d", w.Code)
+	}
+	if w := patch(location, split, data[split:], "Upload-Checksum", "sha1 "+base64.StdEncoding.EncodeToString(make([]byte, sha1.Size))); w.Code != 460 {
+		t.Errorf("patch with a wrong checksum: got %d", w.Code)
+	}
+	if w := serve(http.MethodHead, location, nil); w.Header().Get("Upload-Offset") != fmt.Sprint(split) {
+		t.Errorf("got offset %s after a checksum mismatch, expected %d", w.Header().Get("Upload-Offset"), split)
+	}
+	sum := sha1.Sum(data[split:])
+	if w := patch(location, split, data[split:], "Upload-Checksum", "sha1 "+base64.StdEncoding.EncodeToString(sum[:])); w.Code != http.StatusNoContent {
+		t.Fatalf("patch: got %d %s", w.Code, w.Body)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/big.bin"); err != nil || !bytes.Equal(b, data) {
+		t.Errorf("uploaded file differs (%v)", err)
+	}
+	// a client which lost the last response sees the upload as complete
+	if w := serve(http.MethodHead, location, nil); w.Code != http.StatusOK || w.Header().Get("Upload-Offset") != fmt.Sprint(len(data)) || w.Header().Get("Upload-Length") != fmt.Sprint(len(data)) {
+		t.Errorf("head of a completed upload: got %d %v", w.Code, w.Header())
+	}
+	if w := patch(location, len(data), nil); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != fmt.Sprint(len(data)) {
+		t.Errorf("patch of a completed upload: got %d %s", w.Code, w.Body)
+	}
+
+	w = serve(http.MethodPost, "/files", nil, "Upload-Length", "10", "Upload-Metadata", metadata)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: got %d %s", w.Code, w.Body)
+	}
+	location = w.Header().Get("Location")
+	if w := serve(http.MethodDelete, location, nil); w.Code != http.StatusNoContent {
+		t.Errorf("delete: got %d %s", w.Code, w.Body)
+	}
+	if w := patch(location, 0, []byte("0123456789")); w.Code != http.StatusGone {
+		t.Errorf("patch of a deleted upload: got %d", w.Code)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/big.bin"); err != nil || !bytes.Equal(b, data) {
+		t.Errorf("uploaded file changed (%v)", err)
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/a.txt", []byte("shared"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	key := []byte("secret")
+	h := http_handler.NewSignedHandler(key, http_handler.NewFileHandler(client.NewClient("go.micro.srv.file", c, nil), http_handler.WithPrefix("/shared")))
+	sign := func(key []byte, method, target string, expires time.Duration, options ...http_handler.SignOption) string {
+		u, err := http_handler.Sign(key, method, target, time.Now().Add(expires), options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	serve := func(method, target, contentType, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	get := sign(key, http.MethodGet, "/shared/a.txt", time.Minute)
+	if w := serve(http.MethodGet, get, "", ""); w.Code != http.StatusOK || w.Body.String() != "shared" {
+		t.Errorf("got %d %q, expected 'shared'", w.Code, w.Body)
+	}
+	for name, target := range map[string]string{
+		"unsigned":    "/shared/a.txt",
+		"other path":  strings.Replace(get, "a.txt", "b.txt", 1),
+		"other key":   sign([]byte("other"), http.MethodGet, "/shared/a.txt", time.Minute),
+		"expired":     sign(key, http.MethodGet, "/shared/a.txt", -time.Minute),
+		"other query": strings.Replace(get, "X-File-Expires=", "X-File-Expires=1", 1),
+	} {
+		if w := serve(http.MethodGet, target, "", ""); w.Code != http.StatusForbidden {
+			t.Errorf("%s: got %d, expected 403", name, w.Code)
+		}
+	}
+	head := sign(key, http.MethodHead, "/shared/a.txt", time.Minute)
+	if w := serve(http.MethodHead, head, "", ""); w.Code != http.StatusOK || w.Header().Get("Content-Length") != "6" || w.Body.Len() != 0 {
+		t.Errorf("head: got %d %q, expected the size without the content", w.Code, w.Header().Get("Content-Length"))
+	}
+	if w := serve(http.MethodPut, get, "text/plain", "overwritten"); w.Code != http.StatusForbidden {
+		t.Errorf("other method: got %d, expected 403", w.Code)
+	}
+
+	if _, err := http_handler.Sign(key, http.MethodPost, "/shared/up", time.Now().Add(time.Minute)); err == nil {
+		t.Error("signed a POST url")
+	}
+	if w := serve(http.MethodPost, strings.Replace(get, "/a.txt", "", 1), "multipart/form-data; boundary=x", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("post: got %d, expected 405", w.Code)
+	}
+
+	put := sign(key, http.MethodPut, "/shared/up/b.txt", time.Minute, http_handler.SignMaxSize(10), http_handler.SignContentType("text/plain"))
+	if w := serve(http.MethodPut, put, "application/json", "{}"); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("other content type: got %d, expected 415", w.Code)
+	}
+	r := httptest.NewRequest(http.MethodPut, put, strings.NewReader("more than ten bytes"))
+	r.Header.Set("Content-Type", "text/plain")
+	// the size is unknown so that the body is checked while it is read
+	r.ContentLength = -1
+	w := httptest.NewRecorder()
+	if h.ServeHTTP(w, r); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too large: got %d, expected 413", w.Code)
+	}
+	if ok, _ := afero.Exists(fs, "/srv/up/b.txt"); ok {
+		t.Error("too large upload stored")
+	}
+	if w := serve(http.MethodPut, put, "text/plain; charset=utf-8", "uploaded"); w.Code != http.StatusOK {
+		t.Errorf("got %d: %s", w.Code, w.Body)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/up/b.txt"); err != nil || string(b) != "uploaded" {
+		t.Errorf("got %q (%v), expected 'uploaded'", b, err)
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/a.txt", []byte("a"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv", handler.WithAuthorizer(handler.NewTokenAuthorizer(map[string]string{"token": "alice"})))
+	defer cancel()
+
+	cl := client.NewClient("go.micro.srv.file", c, nil)
+	if _, err := cl.Stat("a.txt"); err != client.ErrUnauthenticated {
+		t.Errorf("got %v, expected %v", err, client.ErrUnauthenticated)
+	}
+	authorized := cl.WithContext(metadata.NewContext(context.Background(), metadata.Metadata{"Authorization": "Bearer token"}))
+	if _, err := authorized.Stat("a.txt"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	h := http_handler.NewFileHandler(cl, http_handler.WithHeaderMatcher(http_handler.ForwardHeaders("Authorization")))
+	for token, code := range map[string]int{"": http.StatusUnauthorized, "other": http.StatusUnauthorized, "token": http.StatusOK} {
+		r := httptest.NewRequest(http.MethodGet, "/a.txt", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		if h.ServeHTTP(w, r); w.Code != code {
+			t.Errorf("token %q: got %d, expected %d", token, w.Code, code)
+		}
+	}
+}
+
+func TestWebDAV(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/hello.txt", []byte("hello"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	h := webdav_handler.NewWebDAVHandler(client.NewClient("go.micro.srv.file", c, nil), webdav_handler.WithPrefix("/dav"))
+	do := func(method, target string, body io.Reader, header ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, body)
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := do("MKCOL", "/dav/dir", nil); w.Code != http.StatusCreated {
+		t.Fatalf("mkcol: got %d", w.Code)
+	}
+	if w := do(http.MethodPut, "/dav/dir/new.txt", strings.NewReader("new file")); w.Code != http.StatusCreated {
+		t.Fatalf("put: got %d", w.Code)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/new.txt"); err != nil || string(b) != "new file" {
+		t.Errorf("got %q (%v), expected 'new file'", b, err)
+	}
+	if w := do("COPY", "/dav/hello.txt", nil, "Destination", "/dav/dir/copy.txt"); w.Code != http.StatusCreated {
+		t.Errorf("copy: got %d", w.Code)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/copy.txt"); err != nil || string(b) != "hello" {
+		t.Errorf("got %q (%v), expected 'hello'", b, err)
+	}
+	if w := do("MOVE", "/dav/dir/new.txt", nil, "Destination", "/dav/moved.txt"); w.Code != http.StatusCreated {
+		t.Errorf("move: got %d", w.Code)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/moved.txt"); err != nil || string(b) != "new file" {
+		t.Errorf("got %q (%v), expected 'new file'", b, err)
+	}
+
+	w := do("PROPFIND", "/dav/", nil, "Depth", "1")
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("propfind: got %d", w.Code)
+	}
+	for _, name := range []string{"/dav/hello.txt", "/dav/moved.txt", "/dav/dir/"} {
+		if !strings.Contains(w.Body.String(), "<D:href>"+name+"</D:href>") {
+			t.Errorf("%s missing from %s", name, w.Body)
+		}
+	}
+	if strings.Contains(w.Body.String(), "copy.txt") {
+		t.Errorf("depth 1 listed a nested file: %s", w.Body)
+	}
+
+	lock := `<?xml version="1.0" encoding="utf-8"?><D:lockinfo xmlns:D="DAV:"><D:lockscope><D:exclusive/></D:lockscope><D:locktype><D:write/></D:locktype></D:lockinfo>`
+	w = do("LOCK", "/dav/hello.txt", strings.NewReader(lock), "Timeout", "Second-60")
+	token := w.Header().Get("Lock-Token")
+	if w.Code != http.StatusOK || token == "" {
+		t.Fatalf("lock: got %d", w.Code)
+	}
+	if w := do(http.MethodDelete, "/dav/hello.txt", nil); w.Code != http.StatusLocked {
+		t.Errorf("delete locked: got %d", w.Code)
+	}
+	if w := do("UNLOCK", "/dav/hello.txt", nil, "Lock-Token", token); w.Code != http.StatusNoContent {
+		t.Errorf("unlock: got %d", w.Code)
+	}
+	if w := do(http.MethodDelete, "/dav/dir", nil); w.Code != http.StatusNoContent {
+		t.Errorf("delete: got %d", w.Code)
+	}
+	if ok, _ := afero.Exists(fs, "/srv/dir"); ok {
+		t.Error("directory not removed")
+	}
+}
+
+func TestS3(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	// the parents created by the MemMapFs are not directories
+	for _, dir := range []string{"/srv", "/srv/bucket", "/srv/bucket/dir", "/srv/bucket/dir/sub"} {
+		if err := fs.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, data := range map[string]string{"dir/a.txt": "a", "dir/sub/b.txt": "b", "c.txt": "c"} {
+		if err := afero.WriteFile(fs, "/srv/bucket/"+name, []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, cancel := startServer(t, fs, "/srv")
+	defer cancel()
+
+	srv := httptest.NewServer(s3_handler.NewS3Handler(client.NewClient("go.micro.srv.file", c, nil), s3_handler.WithCredentials("access", "secret")))
+	defer srv.Close()
+	newRequest := func(method, target string, body []byte) *http.Request {
+		r, err := http.NewRequest(method, srv.URL+target, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256(body)
+		r.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+		return r
+	}
+	send := func(r *http.Request, v interface{}) (int, []byte) {
+		rsp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rsp.Body.Close()
+		b, err := ioutil.ReadAll(rsp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != nil {
+			if err := xml.Unmarshal(b, v); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+		}
+		return rsp.StatusCode, b
+	}
+	do := func(method, target string, body []byte, v interface{}) (int, []byte) {
+		return send(s3signer.SignV4(*newRequest(method, target, body), "access", "secret", "", "us-east-1"), v)
+	}
+
+	if code, _ := send(newRequest(http.MethodGet, "/", nil), nil); code != http.StatusForbidden {
+		t.Errorf("anonymous request: got %d", code)
+	}
+	if code, _ := send(s3signer.SignV4(*newRequest(http.MethodGet, "/", nil), "access", "wrong", "", "us-east-1"), nil); code != http.StatusForbidden {
+		t.Errorf("wrong secret: got %d", code)
+	}
+	r := s3signer.SignV4(*newRequest(http.MethodGet, "/", nil), "access", "secret", "", "us-east-1")
+	r.Header.Set("Authorization", strings.Replace(r.Header.Get("Authorization"), "SignedHeaders=host;", "SignedHeaders=", 1))
+	if code, _ := send(r, nil); code != http.StatusBadRequest {
+		t.Errorf("unsigned host: got %d", code)
+	}
+
+	var buckets struct {
+		Names []string `xml:"Buckets>Bucket>Name"`
+	}
+	if code, _ := do(http.MethodGet, "/", nil, &buckets); code != http.StatusOK || !equal(buckets.Names, []string{"bucket"}) {
+		t.Errorf("got %d %v, expected the bucket", code, buckets.Names)
+	}
+
+	if code, b := do(http.MethodPut, "/bucket/new/x.txt", []byte("new file"), nil); code != http.StatusOK {
+		t.Fatalf("put: got %d %s", code, b)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/bucket/new/x.txt"); err != nil || string(b) != "new file" {
+		t.Errorf("got %q (%v), expected 'new file'", b, err)
+	}
+	r = newRequest(http.MethodPut, "/bucket/y.txt", []byte("tampered"))
+	sum := sha256.Sum256([]byte("signed"))
+	r.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	if code, _ := send(s3signer.SignV4(*r, "access", "secret", "", "us-east-1"), nil); code != http.StatusBadRequest {
+		t.Errorf("tampered payload: got %d", code)
+	}
+	if ok, _ := afero.Exists(fs, "/srv/bucket/y.txt"); ok {
+		t.Error("tampered payload stored")
+	}
+
+	var list struct {
+		Keys     []string `xml:"Contents>Key"`
+		Prefixes []string `xml:"CommonPrefixes>Prefix"`
+	}
+	if code, _ := do(http.MethodGet, "/bucket?list-type=2&prefix=dir/&delimiter=/", nil, &list); code != http.StatusOK {
+		t.Fatalf("list: got %d", code)
+	}
+	if !equal(list.Keys, []string{"dir/a.txt"}) || !equal(list.Prefixes, []string{"dir/sub/"}) {
+		t.Errorf("unexpected listing %v %v", list.Keys, list.Prefixes)
+	}
+	list.Keys, list.Prefixes = nil, nil
+	if code, _ := do(http.MethodGet, "/bucket?list-type=2", nil, &list); code != http.StatusOK {
+		t.Fatalf("list: got %d", code)
+	}
+	if !equal(list.Keys, []string{"c.txt", "dir/a.txt", "dir/sub/b.txt", "new/x.txt"}) || len(list.Prefixes) != 0 {
+		t.Errorf("unexpected listing %v %v", list.Keys, list.Prefixes)
+	}
+
+	r = newRequest(http.MethodGet, "/bucket/new/x.txt", nil)
+	r.Header.Set("Range", "bytes=4-7")
+	if code, b := send(s3signer.SignV4(*r, "access", "secret", "", "us-east-1"), nil); code != http.StatusPartialContent || string(b) != "file" {
+		t.Errorf("range: got %d %q", code, b)
+	}
+	r, err := http.NewRequest(http.MethodGet, srv.URL+"/bucket/c.txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, b := send(s3signer.PreSignV4(*r, "access", "secret", "", "us-east-1", 60), nil); code != http.StatusOK || string(b) != "c" {
+		t.Errorf("presigned: got %d %q", code, b)
+	}
+
+	var e struct {
+		Code string
+	}
+	var upload struct {
+		UploadId string
+	}
+	if code, _ := do(http.MethodPost, "/bucket/multi.bin?uploads", nil, &upload); code != http.StatusOK || upload.UploadId == "" {
+		t.Fatalf("create multipart upload: got %d", code)
+	}
+	parts := [][]byte{bytes.Repeat([]byte("1"), client.BlockSize+10), []byte("22")}
+	complete, wrong := "<CompleteMultipartUpload>", "<CompleteMultipartUpload>"
+	for i, p := range parts {
+		code, b := do(http.MethodPut, fmt.Sprintf("/bucket/multi.bin?partNumber=%d&uploadId=%s", i+1, upload.UploadId), p, nil)
+		if code != http.StatusOK {
+			t.Fatalf("upload part: got %d %s", code, b)
+		}
+		sum := md5.Sum(p)
+		complete += fmt.Sprintf(`<Part><PartNumber>%d</PartNumber><ETag>"%x"</ETag></Part>`, i+1, sum)
+		wrong += fmt.Sprintf("<Part><PartNumber>%d</PartNumber><ETag>etag</ETag></Part>", i+1)
+	}
+	complete += "</CompleteMultipartUpload>"
+	wrong += "</CompleteMultipartUpload>"
+	if code, _ := do(http.MethodPost, "/bucket/other.bin?uploadId="+upload.UploadId, []byte(complete), &e); code != http.StatusNotFound || e.Code != "NoSuchUpload" {
+		t.Errorf("complete of another key: got %d %s", code, e.Code)
+	}
+	if code, _ := do(http.MethodPost, "/bucket/multi.bin?uploadId="+upload.UploadId, []byte(wrong), &e); code != http.StatusBadRequest || e.Code != "InvalidPart" {
+		t.Errorf("complete with wrong etags: got %d %s", code, e.Code)
+	}
+	if code, b := do(http.MethodPost, "/bucket/multi.bin?uploadId="+upload.UploadId, []byte(complete), nil); code != http.StatusOK {
+		t.Fatalf("complete multipart upload: got %d %s", code, b)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/bucket/multi.bin"); err != nil || !bytes.Equal(b, append(parts[0], parts[1]...)) {
+		t.Errorf("multipart object differs (%v)", err)
+	}
+	// only the state of the committed upload is left
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 1 || infos[0].Name() != upload.UploadId+".json" {
+		t.Errorf("multipart parts left (%v)", err)
+	}
+
+	if code, _ := do(http.MethodPost, "/bucket/aborted.bin?uploads", nil, &upload); code != http.StatusOK {
+		t.Fatalf("create multipart upload: got %d", code)
+	}
+	part := fmt.Sprintf("/bucket/aborted.bin?partNumber=1&uploadId=%s", upload.UploadId)
+	if code, b := do(http.MethodPut, part, []byte("part"), nil); code != http.StatusOK {
+		t.Fatalf("upload part: got %d %s", code, b)
+	}
+	if code, _ := do(http.MethodDelete, "/bucket/aborted.bin?uploadId="+upload.UploadId, nil, nil); code != http.StatusNoContent {
+		t.Errorf("abort multipart upload: got %d", code)
+	}
+	if code, _ := do(http.MethodPut, part, []byte("part"), &e); code != http.StatusNotFound || e.Code != "NoSuchUpload" {
+		t.Errorf("upload part of an aborted upload: got %d %s", code, e.Code)
+	}
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 1 {
+		t.Errorf("aborted multipart upload left (%v)", err)
+	}
+
+	if code, _ := do(http.MethodDelete, "/bucket/c.txt", nil, nil); code != http.StatusNoContent {
+		t.Errorf("delete: got %d", code)
+	}
+	if code, _ := do(http.MethodGet, "/bucket/c.txt", nil, &e); code != http.StatusNotFound || e.Code != "NoSuchKey" {
+		t.Errorf("got %d %s, expected NoSuchKey", code, e.Code)
+	}
+	if code, _ := do(http.MethodGet, "/bucket/../../etc/passwd", nil, nil); code == http.StatusOK {
+		t.Error("path outside of the bucket served")
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
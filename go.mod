@@ -5,6 +5,7 @@ go 1.13
 require (
 	github.com/golang/protobuf v1.3.2
 	github.com/micro/go-micro v1.18.0
+	github.com/minio/minio-go v6.0.14+incompatible
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/afero v1.1.2
 	github.com/spf13/cobra v0.0.5
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"golang.org/x/net/context"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// chowner is implemented by the afero.Fs supporting Chown
+type chowner interface {
+	Chown(name string, uid, gid int) error
+}
+
+// target returns the file of the session id when it is set, and the path of
+// the file the method applies to otherwise. The session must be opened for
+// writing, as the changes through the filename need WriteAccess.
+func (h *handler) target(ctx context.Context, method, id, filename string) (afero.File, string, error) {
+	if id != "" {
+		file, err := h.sessionFile(ctx, method, id)
+		if err != nil {
+			return nil, "", err
+		}
+		if f, ok := file.(*lockedFile); ok && f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+			return nil, "", newError(proto.ErrorCode_PERMISSION_DENIED, "session %s is read only", id)
+		}
+		if !h.sameFile(file, file.Name()) {
+			return nil, "", newError(proto.ErrorCode_NOT_FOUND, "the file of session %s was moved or removed", id)
+		}
+		return file, file.Name(), nil
+	}
+	path, err := h.authorize(ctx, method, WriteAccess, filename)
+	if err != nil {
+		return nil, "", err
+	}
+	return nil, path, nil
+}
+
+// sameFile reports whether path is still the file of a session. Only the os
+// filesystem can tell the files apart, the path is trusted elsewhere.
+func (h *handler) sameFile(file afero.File, path string) bool {
+	if _, ok := h.fs.(*afero.OsFs); !ok {
+		return true
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	pi, err := h.fs.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(fi, pi)
+}
+
+// Truncate changes the size of the session file or of filename
+func (h *handler) Truncate(ctx context.Context, req *proto.TruncateRequest, rsp *proto.TruncateResponse) error {
+	if req.Size < 0 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid size %d", req.Size)
+	}
+	file, path, err := h.target(ctx, "Truncate", req.Id, req.Filename)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		if file, err = h.fs.OpenFile(path, os.O_WRONLY, 0); err != nil {
+			return h.fsError(err)
+		}
+		defer file.Close()
+	}
+	if err := file.Truncate(req.Size); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Truncate %s, size=%d", path, req.Size)
+	return nil
+}
+
+// Sync commits the content of the session file or of filename to stable storage
+func (h *handler) Sync(ctx context.Context, req *proto.SyncRequest, rsp *proto.SyncResponse) error {
+	file, path, err := h.target(ctx, "Sync", req.Id, req.Filename)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		if file, err = h.fs.Open(path); err != nil {
+			return h.fsError(err)
+		}
+		defer file.Close()
+	}
+	if err := file.Sync(); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Sync %s", path)
+	return nil
+}
+
+func (h *handler) Chmod(ctx context.Context, req *proto.ChmodRequest, rsp *proto.ChmodResponse) error {
+	_, path, err := h.target(ctx, "Chmod", req.Id, req.Filename)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(req.Mode) & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+	if err := h.fs.Chmod(path, mode); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Chmod %s, mode=%s", path, mode)
+	return nil
+}
+
+// Chown changes the owner of the session file or of filename, it is only
+// supported on the os filesystem and on the afero.Fs implementing it
+func (h *handler) Chown(ctx context.Context, req *proto.ChownRequest, rsp *proto.ChownResponse) error {
+	_, path, err := h.target(ctx, "Chown", req.Id, req.Filename)
+	if err != nil {
+		return err
+	}
+	switch fs := h.fs.(type) {
+	case chowner:
+		err = fs.Chown(path, int(req.Uid), int(req.Gid))
+	case *afero.OsFs:
+		err = os.Chown(path, int(req.Uid), int(req.Gid))
+	default:
+		return newError(proto.ErrorCode_NOT_SUPPORTED, "chown is not supported by the filesystem")
+	}
+	if err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Chown %s, uid=%d, gid=%d", path, req.Uid, req.Gid)
+	return nil
+}
+
+// Chtimes changes the access and modification times, in nanoseconds since
+// the epoch, of the session file or of filename
+func (h *handler) Chtimes(ctx context.Context, req *proto.ChtimesRequest, rsp *proto.ChtimesResponse) error {
+	_, path, err := h.target(ctx, "Chtimes", req.Id, req.Filename)
+	if err != nil {
+		return err
+	}
+	if err := h.fs.Chtimes(path, time.Unix(0, req.Atime), time.Unix(0, req.Mtime)); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Chtimes %s, atime=%d, mtime=%d", path, req.Atime, req.Mtime)
+	return nil
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/micro/go-micro/metadata"
+	"github.com/spf13/afero"
+	"golang.org/x/net/context"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// AuthorizationMetadataKey is the metadata key holding the bearer token of
+// the caller
+const AuthorizationMetadataKey = "Authorization"
+
+var (
+	// ErrUnauthenticated is returned by an Authorizer which does not accept
+	// the credentials of the caller
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned by an Authorizer when the caller may not
+	// perform the operation
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Access is the kind of access of an operation to a file
+type Access int
+
+const (
+	// ReadAccess reads the files and lists the directories
+	ReadAccess Access = iota
+	// WriteAccess creates, changes and removes the files, it implies ReadAccess
+	WriteAccess
+)
+
+func (a Access) String() string {
+	if a == WriteAccess {
+		return "write"
+	}
+	return "read"
+}
+
+// Operation is a call of the File service, Method is its name
+type Operation struct {
+	Method string
+	Access Access
+}
+
+// Authorizer decides whether the caller described by md may perform op on
+// path, the slash separated path relative to the served directory, "" being
+// the served directory itself. It returns the authenticated subject of the
+// caller, which owns the sessions and the uploads it opens. Every use of a
+// session or an upload is authorized again on its path.
+type Authorizer interface {
+	Authorize(md metadata.Metadata, op Operation, path string) (string, error)
+}
+
+// Rule grants Access to the files below Path to the Subjects, "*" matching
+// every authenticated subject
+type Rule struct {
+	Path     string
+	Subjects []string
+	Access   Access
+}
+
+func (r *Rule) match(subject, path string) bool {
+	p := strings.Trim(r.Path, "/")
+	if p != "" && path != p && !strings.HasPrefix(path, p+"/") {
+		return false
+	}
+	for _, s := range r.Subjects {
+		if s == "*" || s == subject {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed reports whether one of rules lets subject perform op on path,
+// all the operations are allowed when there is no rule
+func allowed(rules []Rule, subject string, op Operation, path string) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	for i := range rules {
+		if rules[i].Access >= op.Access && rules[i].match(subject, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken returns the bearer token of the AuthorizationMetadataKey
+// metadata value
+func bearerToken(md metadata.Metadata) string {
+	for k, v := range md {
+		if !strings.EqualFold(k, AuthorizationMetadataKey) {
+			continue
+		}
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			return strings.TrimSpace(v[7:])
+		}
+	}
+	return ""
+}
+
+type tokenAuthorizer struct {
+	tokens map[string]string
+	rules  []Rule
+}
+
+func (a *tokenAuthorizer) Authorize(md metadata.Metadata, op Operation, path string) (string, error) {
+	token := bearerToken(md)
+	if token == "" {
+		return "", ErrUnauthenticated
+	}
+	subject, ok := "", false
+	// every token is compared so that the time does not depend on the match
+	for t, s := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			subject, ok = s, true
+		}
+	}
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	if !allowed(a.rules, subject, op, path) {
+		return "", ErrForbidden
+	}
+	return subject, nil
+}
+
+// NewTokenAuthorizer authenticates the callers with the static bearer
+// tokens of their Authorization metadata, tokens maps each token to the
+// subject the rules apply to
+func NewTokenAuthorizer(tokens map[string]string, rules ...Rule) Authorizer {
+	return &tokenAuthorizer{tokens: tokens, rules: rules}
+}
+
+// authorize resolves name like resolve and checks that the caller may
+// perform the method with access on it
+func (h *handler) authorize(ctx context.Context, method string, access Access, name string) (string, error) {
+	path, err := h.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := h.authorizePath(ctx, method, access, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// authorizeOwner is authorize also returning the identity of the caller,
+// the owner of the sessions and the uploads it opens
+func (h *handler) authorizeOwner(ctx context.Context, method string, access Access, name string) (string, string, error) {
+	path, err := h.resolve(name)
+	if err != nil {
+		return "", "", err
+	}
+	owner, err := h.authorizePath(ctx, method, access, path)
+	if err == nil {
+		err = h.checkOwner(owner)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return path, owner, nil
+}
+
+// checkOwner rejects the callers without identity when an Authorizer is
+// set, their sessions and uploads would be shared by all of them
+func (h *handler) checkOwner(owner string) error {
+	if owner == "" && h.opts.authorizer != nil {
+		return newError(proto.ErrorCode_UNAUTHENTICATED, "sessions need an authenticated caller")
+	}
+	return nil
+}
+
+// authorizePath checks that the caller may perform the method with access
+// on the resolved path, and returns its identity: the subject authenticated
+// by the Authorizer, or the CallerIdentity without one
+func (h *handler) authorizePath(ctx context.Context, method string, access Access, path string) (string, error) {
+	if h.opts.authorizer == nil {
+		return h.opts.caller(ctx), nil
+	}
+	md, _ := metadata.FromContext(ctx)
+	rel := h.clientPath(path)
+	if rel == "." {
+		rel = ""
+	}
+	subject, err := h.opts.authorizer.Authorize(md, Operation{Method: method, Access: access}, rel)
+	switch {
+	case err == nil:
+		return subject, nil
+	case errors.Is(err, ErrUnauthenticated):
+		return "", newError(proto.ErrorCode_UNAUTHENTICATED, "%v", err)
+	}
+	return "", newError(proto.ErrorCode_PERMISSION_DENIED, "%s access to /%s denied: %v", access, rel, err)
+}
+
+// sessionOwner authorizes the caller again for method on the path of the
+// session id, with the access the session was opened with, and returns its
+// identity
+func (h *handler) sessionOwner(ctx context.Context, method, id string) (string, error) {
+	path, access, err := h.session.Path(id)
+	if err != nil {
+		return "", err
+	}
+	owner, err := h.authorizePath(ctx, method, access, path)
+	if err != nil {
+		return "", err
+	}
+	return owner, h.checkOwner(owner)
+}
+
+// sessionFile returns the file of the session id once the caller is
+// authorized by sessionOwner
+func (h *handler) sessionFile(ctx context.Context, method, id string) (afero.File, error) {
+	owner, err := h.sessionOwner(ctx, method, id)
+	if err != nil {
+		return nil, err
+	}
+	return h.session.Get(id, owner)
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/micro/go-micro/metadata"
+	"golang.org/x/net/context"
+)
+
+// CallerMetadataKey is the metadata key holding the caller identity
+const CallerMetadataKey = "Caller-Id"
+
+// metadataCaller is the default CallerIdentity, reading the CallerMetadataKey
+// metadata value. It is set by the caller itself, so the ownership of the
+// sessions is advisory: it keeps well behaved callers apart, and a caller
+// which does not set it shares the sessions of the other ones.
+func metadataCaller(ctx context.Context) string {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	for k, v := range md {
+		if strings.EqualFold(k, CallerMetadataKey) {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/micro/go-micro/errors"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// httpCodes are the micro error codes of the proto.ErrorCode, the
+// proto.ErrorCode itself is carried in the error status
+var httpCodes = map[proto.ErrorCode]int32{
+	proto.ErrorCode_UNKNOWN:           http.StatusInternalServerError,
+	proto.ErrorCode_INTERNAL:          http.StatusInternalServerError,
+	proto.ErrorCode_INVALID_ARGUMENT:  http.StatusBadRequest,
+	proto.ErrorCode_NOT_FOUND:         http.StatusNotFound,
+	proto.ErrorCode_EXISTS:            http.StatusConflict,
+	proto.ErrorCode_PERMISSION_DENIED: http.StatusForbidden,
+	proto.ErrorCode_IS_DIRECTORY:      http.StatusBadRequest,
+	proto.ErrorCode_NOT_DIRECTORY:     http.StatusBadRequest,
+	proto.ErrorCode_NOT_EMPTY:         http.StatusConflict,
+	proto.ErrorCode_SESSION_NOT_FOUND: http.StatusNotFound,
+	proto.ErrorCode_SESSION_EXPIRED:   http.StatusGone,
+	proto.ErrorCode_QUOTA_EXCEEDED:    http.StatusTooManyRequests,
+	proto.ErrorCode_CHECKSUM_MISMATCH: http.StatusUnprocessableEntity,
+	proto.ErrorCode_NOT_SUPPORTED:     http.StatusNotImplemented,
+	proto.ErrorCode_UNAUTHENTICATED:   http.StatusUnauthorized,
+}
+
+// newError returns a micro error with the http code matching code and code
+// as status, so that clients can tell the errors apart
+func newError(code proto.ErrorCode, format string, a ...interface{}) error {
+	return &errors.Error{
+		Id:     "go.micro.srv.file",
+		Code:   httpCodes[code],
+		Detail: fmt.Sprintf(format, a...),
+		Status: code.String(),
+	}
+}
+
+// errnoCodes maps the system errors which have no os package predicate
+var errnoCodes = map[syscall.Errno]proto.ErrorCode{
+	syscall.EISDIR:    proto.ErrorCode_IS_DIRECTORY,
+	syscall.ENOTDIR:   proto.ErrorCode_NOT_DIRECTORY,
+	syscall.ENOTEMPTY: proto.ErrorCode_NOT_EMPTY,
+	syscall.ENOSPC:    proto.ErrorCode_QUOTA_EXCEEDED,
+	syscall.EDQUOT:    proto.ErrorCode_QUOTA_EXCEEDED,
+	syscall.EINVAL:    proto.ErrorCode_INVALID_ARGUMENT,
+}
+
+// fsError converts an error of the filesystem into a micro error, the paths
+// are made relative to the served directory
+func (h *handler) fsError(err error) error {
+	if _, ok := err.(*errors.Error); ok {
+		return err
+	}
+	code := proto.ErrorCode_INTERNAL
+	// the errnos are checked first as os.IsExist also matches ENOTEMPTY
+	if c, ok := errnoCodes[underlyingErrno(err)]; ok {
+		code = c
+	} else if os.IsNotExist(err) {
+		code = proto.ErrorCode_NOT_FOUND
+	} else if os.IsExist(err) {
+		code = proto.ErrorCode_EXISTS
+	} else if os.IsPermission(err) {
+		code = proto.ErrorCode_PERMISSION_DENIED
+	}
+	return newError(code, "%s", h.errorDetail(err))
+}
+
+func underlyingErrno(err error) syscall.Errno {
+	switch e := err.(type) {
+	case *os.PathError:
+		err = e.Err
+	case *os.LinkError:
+		err = e.Err
+	case *os.SyscallError:
+		err = e.Err
+	}
+	errno, _ := err.(syscall.Errno)
+	return errno
+}
+
+// errorDetail returns the message of err with the paths as seen by the clients
+func (h *handler) errorDetail(err error) string {
+	switch e := err.(type) {
+	case *os.PathError:
+		return fmt.Sprintf("%s %s: %v", e.Op, h.clientPath(e.Path), e.Err)
+	case *os.LinkError:
+		return fmt.Sprintf("%s %s %s: %v", e.Op, h.clientPath(e.Old), h.clientPath(e.New), e.Err)
+	}
+	return err.Error()
+}
+
+// clientPath returns path relative to the served directory, the paths
+// outside of it are reduced to their base name
+func (h *handler) clientPath(path string) string {
+	if !within(h.dir, path) {
+		return filepath.Base(path)
+	}
+	rel, err := filepath.Rel(h.dir, path)
+	if err != nil {
+		return filepath.Base(path)
+	}
+	return filepath.ToSlash(rel)
+}
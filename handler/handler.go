@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/micro/go-micro/errors"
 	"github.com/micro/go-micro/server"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
@@ -17,63 +21,187 @@ import (
 )
 
 // NewHandler is a handler that can be registered with a micro Server
-func NewHandler(dir string, fs afero.Fs) (proto.FileHandler, error) {
+func NewHandler(dir string, fs afero.Fs, options ...Option) (proto.FileHandler, error) {
 	logrus.Tracef("Creating File handler on directory : %s", dir)
-	if i, err := fs.Stat(dir); err != nil || !i.IsDir(){
+	if i, err := fs.Stat(dir); err != nil || !i.IsDir() {
 		return nil, fmt.Errorf("%s is not a valid directory", dir)
 	}
-	return &handler{
-		dir: dir,
-		fs:  fs,
-		session: &session{
-			files: make(map[int64]afero.File),
-		},
-	}, nil
+	o := &Options{}
+	for _, v := range options {
+		v(o)
+	}
+	if o.context == nil {
+		o.context = context.Background()
+	}
+	if o.caller == nil {
+		o.caller = metadataCaller
+	}
+	if o.fileMode == 0 {
+		o.fileMode = defaultFileMode
+	}
+	if o.uploadDir == "" {
+		o.uploadDir = filepath.Join(dir, uploadDir)
+	}
+	o.uploadDir = filepath.Clean(o.uploadDir)
+	h := &handler{
+		dir:     filepath.Clean(dir),
+		fs:      fs,
+		opts:    o,
+		session: newSession(o.maxSessions),
+	}
+	if o.idleTimeout > 0 {
+		go h.session.reaper(o.context, o.idleTimeout)
+	}
+	go h.uploadReaper(o.context)
+	return h, nil
 }
 
 // RegisterHandler is a convenience method for registering a handler
-func RegisterHandler(s server.Server, dir string, fs afero.Fs) error {
-	h, err := NewHandler(dir, fs)
+func RegisterHandler(s server.Server, dir string, fs afero.Fs, options ...Option) error {
+	h, err := NewHandler(dir, fs, options...)
 	if err != nil {
 		return err
 	}
-	return proto.RegisterFileHandler(s,h)
+	return proto.RegisterFileHandler(s, h)
+}
+
+const (
+	defaultChunkSize = 512 * 1024
+	maxChunkSize     = 4 * 1024 * 1024
+)
+
+// defaultFileMode is the mode of the new files unless WithFileMode is used,
+// the files created through a temporary file do not get the umask applied
+const defaultFileMode os.FileMode = 0644
+
+// features lists the optional capabilities of the handler, reported by Features
+var features = []string{"read_stream", "write_stream", "checksum", "upload_session", "atomic_create"}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// blockChecksum returns the big endian CRC32C of b, as carried by Read and Write
+func blockChecksum(b []byte) []byte {
+	sum := make([]byte, 4)
+	binary.BigEndian.PutUint32(sum, crc32.Checksum(b, castagnoli))
+	return sum
 }
 
 type handler struct {
 	dir     string
 	session *session
+	uploads uploadLocks
 	fs      afero.Fs
+	opts    *Options
+}
+
+func (h *handler) Features(ctx context.Context, req *proto.FeaturesRequest, rsp *proto.FeaturesResponse) error {
+	rsp.Features = features
+	return nil
 }
 
 func (h *handler) Open(ctx context.Context, req *proto.OpenRequest, rsp *proto.OpenResponse) error {
-	path := filepath.Join(h.dir, req.Filename)
+	path, owner, err := h.authorizeOwner(ctx, "Open", ReadAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	if err := h.session.Reserve(); err != nil {
+		return err
+	}
 	file, err := h.fs.Open(path)
 	if err != nil {
-		errm := strings.Replace(err.Error(), h.dir, "", -1)
-		return errors.BadRequest("go.micro.srv.file", errm)
+		h.session.Release()
+		return h.fsError(err)
 	}
 
-	rsp.Id = h.session.Add(file)
+	rsp.Id, err = h.session.Add(file, path, os.O_RDONLY, owner, nil)
+	if err != nil {
+		return err
+	}
 	rsp.Result = true
 
-	logrus.Tracef("Open %s, sessionId=%d", req.Filename, rsp.Id)
+	logrus.Tracef("Open %s, sessionId=%s", req.Filename, rsp.Id)
+
+	return nil
+}
+
+// openFlags maps the portable proto.OpenFlag bits to the os package flags
+var openFlags = []struct {
+	proto proto.OpenFlag
+	os    int
+}{
+	{proto.OpenFlag_O_WRONLY, os.O_WRONLY},
+	{proto.OpenFlag_O_RDWR, os.O_RDWR},
+	{proto.OpenFlag_O_APPEND, os.O_APPEND},
+	{proto.OpenFlag_O_CREATE, os.O_CREATE},
+	{proto.OpenFlag_O_EXCL, os.O_EXCL},
+	{proto.OpenFlag_O_SYNC, os.O_SYNC},
+	{proto.OpenFlag_O_TRUNC, os.O_TRUNC},
+}
+
+// OpenFile opens a file like afero.Fs OpenFile, the flags are proto.OpenFlag bits
+func (h *handler) OpenFile(ctx context.Context, req *proto.OpenFileRequest, rsp *proto.OpenFileResponse) error {
+	var flag int
+	for _, v := range openFlags {
+		if req.Flags&uint32(v.proto) != 0 {
+			flag |= v.os
+		}
+	}
+	if flag&os.O_WRONLY != 0 && flag&os.O_RDWR != 0 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid flags %#x", req.Flags)
+	}
+	access := ReadAccess
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		access = WriteAccess
+	}
+	path, owner, err := h.authorizeOwner(ctx, "OpenFile", access, req.Filename)
+	if err != nil {
+		return err
+	}
+	// not every afero.Fs honours O_EXCL
+	if flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL {
+		if _, err := h.fs.Stat(path); err == nil {
+			return newError(proto.ErrorCode_EXISTS, "%s already exists", req.Filename)
+		}
+	}
+	if err := h.session.Reserve(); err != nil {
+		return err
+	}
+	file, err := h.fs.OpenFile(path, flag, os.FileMode(req.Perm)&os.ModePerm)
+	if err != nil {
+		h.session.Release()
+		return h.fsError(err)
+	}
+
+	rsp.Id, err = h.session.Add(file, path, flag, owner, nil)
+	if err != nil {
+		return err
+	}
+
+	logrus.Tracef("OpenFile %s, flags=%#x, sessionId=%s", req.Filename, req.Flags, rsp.Id)
 
 	return nil
 }
 
 func (h *handler) Close(ctx context.Context, req *proto.CloseRequest, rsp *proto.CloseResponse) error {
-	h.session.Delete(req.Id)
-	logrus.Tracef("Close sessionId=%d", req.Id)
+	owner, err := h.sessionOwner(ctx, "Close", req.Id)
+	if err != nil {
+		return err
+	}
+	if err := h.session.Delete(req.Id, owner, req.Abort); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Close sessionId=%s, abort=%v", req.Id, req.Abort)
 	return nil
 }
 
 func (h *handler) Stat(ctx context.Context, req *proto.StatRequest, rsp *proto.StatResponse) error {
-	path := filepath.Join(h.dir, req.Filename)
-	fi, err := h.fs.Stat(path)
-	if os.IsNotExist(err) {
-		errm := strings.Replace(err.Error(), h.dir, "", -1)
-		return errors.BadRequest("go.micro.srv.file", errm)
+	path, err := h.authorize(ctx, "Stat", ReadAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	fi, err := h.lstat(path)
+	if err != nil {
+		return h.fsError(err)
 	}
 
 	if fi.IsDir() {
@@ -84,21 +212,148 @@ func (h *handler) Stat(ctx context.Context, req *proto.StatRequest, rsp *proto.S
 	}
 
 	rsp.LastModified = fi.ModTime().Unix()
+	rsp.Mode = uint32(fi.Mode())
+	rsp.ModTime = fi.ModTime().UnixNano()
+	if fi.Mode()&os.ModeSymlink != 0 {
+		rsp.LinkTarget = h.linkTarget(path)
+	}
+	statSys(fi, rsp)
 	logrus.Tracef("Stat %s, %#v", req.Filename, rsp.String())
 
 	return nil
 }
 
+func (h *handler) List(ctx context.Context, req *proto.ListRequest, rsp *proto.ListResponse) error {
+	if req.Offset < 0 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid offset %d", req.Offset)
+	}
+	path, err := h.authorize(ctx, "List", ReadAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	fi, err := h.fs.Stat(path)
+	if err != nil {
+		return h.fsError(err)
+	}
+	if !fi.IsDir() {
+		return newError(proto.ErrorCode_NOT_DIRECTORY, "%s is not a directory", req.Filename)
+	}
+
+	var files []*proto.FileInfo
+	if req.Recursive {
+		err = afero.Walk(h.fs, path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if p == path {
+				return nil
+			}
+			if h.reserved(p) {
+				return filepath.SkipDir
+			}
+			rel, err := filepath.Rel(path, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, fileInfo(filepath.ToSlash(rel), info))
+			return nil
+		})
+	} else {
+		var infos []os.FileInfo
+		infos, err = afero.ReadDir(h.fs, path)
+		for _, info := range infos {
+			if h.reserved(filepath.Join(path, info.Name())) {
+				continue
+			}
+			files = append(files, fileInfo(info.Name(), info))
+		}
+	}
+	if err != nil {
+		return h.fsError(err)
+	}
+
+	start := req.Offset
+	if start > int64(len(files)) {
+		start = int64(len(files))
+	}
+	end := int64(len(files))
+	if req.Limit > 0 && start+req.Limit < end {
+		end = start + req.Limit
+	}
+	rsp.Files = files[start:end]
+	rsp.Eof = end == int64(len(files))
+
+	logrus.Tracef("List %s, offset=%d, n=%d", req.Filename, req.Offset, len(rsp.Files))
+
+	return nil
+}
+
+// Checksum returns the SHA-256 of a file and, if requested, the CRC32C of each
+// of its blocks
+func (h *handler) Checksum(ctx context.Context, req *proto.ChecksumRequest, rsp *proto.ChecksumResponse) error {
+	if req.BlockSize < 0 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid block size %d", req.BlockSize)
+	}
+	path, err := h.authorize(ctx, "Checksum", ReadAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	file, err := h.fs.Open(path)
+	if err != nil {
+		return h.fsError(err)
+	}
+	defer file.Close()
+	if fi, err := file.Stat(); err != nil {
+		return h.fsError(err)
+	} else if fi.IsDir() {
+		return newError(proto.ErrorCode_IS_DIRECTORY, "%s is a directory", req.Filename)
+	}
+
+	size := req.BlockSize
+	if size == 0 {
+		size = defaultChunkSize
+	}
+	if size > maxChunkSize {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "block size %d exceeds %d", req.BlockSize, maxChunkSize)
+	}
+	hash := sha256.New()
+	buf := make([]byte, size)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := io.ReadFull(file, buf)
+		if n > 0 {
+			hash.Write(buf[:n])
+			rsp.Size += int64(n)
+			if req.Blocks {
+				rsp.Blocks = append(rsp.Blocks, crc32.Checksum(buf[:n], castagnoli))
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return h.fsError(err)
+		}
+	}
+	rsp.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	logrus.Tracef("Checksum %s, size=%d, checksum=%s", req.Filename, rsp.Size, rsp.Checksum)
+
+	return nil
+}
+
 func (h *handler) Read(ctx context.Context, req *proto.ReadRequest, rsp *proto.ReadResponse) error {
-	file := h.session.Get(req.Id)
-	if file == nil {
-		return errors.BadRequest("go.micro.srv.file", "You must call open first.")
+	file, err := h.sessionFile(ctx, "Read", req.Id)
+	if err != nil {
+		return err
 	}
 
 	rsp.Data = make([]byte, req.Size)
 	n, err := file.ReadAt(rsp.Data, req.Offset)
 	if err != nil && err != io.EOF {
-		return errors.InternalServerError("go.micro.srv.file", err.Error())
+		return h.fsError(err)
 	}
 
 	if err == io.EOF {
@@ -107,38 +362,365 @@ func (h *handler) Read(ctx context.Context, req *proto.ReadRequest, rsp *proto.R
 
 	rsp.Size = int64(n)
 	rsp.Data = rsp.Data[:n]
+	rsp.Checksum = blockChecksum(rsp.Data)
+
+	logrus.Tracef("Read sessionId=%s, Offset=%d, n=%d", req.Id, req.Offset, rsp.Size)
+
+	return nil
+}
+
+func (h *handler) ReadStream(ctx context.Context, req *proto.ReadStreamRequest, stream proto.File_ReadStreamStream) error {
+	if req.Offset < 0 || req.Length < 0 || req.ChunkSize < 0 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid range")
+	}
+	path, err := h.authorize(ctx, "ReadStream", ReadAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	file, err := h.fs.Open(path)
+	if err != nil {
+		return h.fsError(err)
+	}
+	defer file.Close()
+
+	size := req.ChunkSize
+	if size == 0 {
+		size = defaultChunkSize
+	}
+	if size > maxChunkSize {
+		size = maxChunkSize
+	}
+	buf := make([]byte, size)
+	offset := req.Offset
+	end := int64(-1)
+	if req.Length > 0 {
+		end = req.Offset + req.Length
+	}
+	for {
+		// stop early if the client went away, Send blocks while it is not consuming
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b := buf
+		if end >= 0 && end-offset < int64(len(b)) {
+			b = b[:end-offset]
+		}
+		n, err := file.ReadAt(b, offset)
+		if err != nil && err != io.EOF {
+			return h.fsError(err)
+		}
+		eof := err == io.EOF || n == 0 || (end >= 0 && offset+int64(n) >= end)
+		if err := stream.Send(&proto.ReadStreamResponse{Offset: offset, Data: b[:n], Eof: eof}); err != nil {
+			return err
+		}
+		offset += int64(n)
+		if eof {
+			break
+		}
+	}
 
-	logrus.Tracef("Read sessionId=%d, Offset=%d, n=%d", req.Id, req.Offset, rsp.Size)
+	logrus.Tracef("ReadStream %s, Offset=%d, n=%d", req.Filename, req.Offset, offset-req.Offset)
 
 	return nil
 }
 
 func (h *handler) Create(ctx context.Context, req *proto.CreateRequest, rsp *proto.CreateResponse) error {
-	path := filepath.Join(h.dir, req.Filename)
+	path, owner, err := h.authorizeOwner(ctx, "Create", WriteAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	// the session is held before the file is truncated
+	if err := h.session.Reserve(); err != nil {
+		return err
+	}
+	if req.Atomic {
+		return h.createAtomic(req.Filename, path, owner, rsp)
+	}
 	file, err := h.fs.Create(path)
 	if err != nil {
-		return errors.InternalServerError("go.micro.srv.file", err.Error())
+		h.session.Release()
+		return h.fsError(err)
+	}
+
+	rsp.Id, err = h.session.Add(file, path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, owner, nil)
+	if err != nil {
+		return err
+	}
+	rsp.Result = true
+
+	logrus.Tracef("Open %s, sessionId=%s", req.Filename, rsp.Id)
+
+	return nil
+}
+
+// createMode returns the mode of a file replacing path: the mode of the
+// file it replaces, or the mode of the new files
+func (h *handler) createMode(path string) os.FileMode {
+	if fi, err := h.fs.Stat(path); err == nil && fi.Mode().IsRegular() {
+		return fi.Mode().Perm()
+	}
+	return h.opts.fileMode
+}
+
+// createAtomic opens a session of owner, held by Reserve, writing to a
+// hidden temporary file next to path, which replaces path when the session
+// is closed and is removed when the session is aborted or reaped
+func (h *handler) createAtomic(name, path, owner string, rsp *proto.CreateResponse) error {
+	if fi, err := h.fs.Stat(path); err == nil && fi.IsDir() {
+		h.session.Release()
+		return newError(proto.ErrorCode_IS_DIRECTORY, "%s is a directory", name)
+	}
+	dir, base := filepath.Split(path)
+	file, err := afero.TempFile(h.fs, dir, "."+base+".")
+	if err != nil {
+		h.session.Release()
+		return h.fsError(err)
+	}
+	temp := file.Name()
+	if err := h.fs.Chmod(temp, h.createMode(path)); err != nil {
+		file.Close()
+		h.fs.Remove(temp)
+		h.session.Release()
+		return h.fsError(err)
+	}
+	done := func(abort bool) error {
+		if abort {
+			logrus.Tracef("Discard %s", temp)
+			return h.fs.Remove(temp)
+		}
+		return h.fs.Rename(temp, path)
 	}
 
-	rsp.Id = h.session.Add(file)
+	rsp.Id, err = h.session.Add(file, path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, owner, done)
+	if err != nil {
+		return err
+	}
 	rsp.Result = true
 
-	logrus.Tracef("Open %s, sessionId=%d", req.Filename, rsp.Id)
+	logrus.Tracef("Open %s atomically, sessionId=%s", name, rsp.Id)
 
 	return nil
 }
 
 func (h *handler) Write(ctx context.Context, req *proto.WriteRequest, rsp *proto.WriteResponse) error {
-	file := h.session.Get(req.Id)
-	if file == nil {
-		return errors.InternalServerError("go.micro.srv.file", "You must call open first.")
+	file, err := h.sessionFile(ctx, "Write", req.Id)
+	if err != nil {
+		return err
+	}
+	if len(req.Checksum) > 0 && !bytes.Equal(req.Checksum, blockChecksum(req.Data)) {
+		return newError(proto.ErrorCode_CHECKSUM_MISMATCH, "checksum mismatch")
 	}
 
 	n, err := file.WriteAt(req.Data, req.Offset)
 	if err != nil && err != io.EOF {
-		return errors.InternalServerError("go.micro.srv.file", err.Error())
+		return h.fsError(err)
 	}
-	logrus.Tracef("Write sessionId=%d, Offset=%d, n=%d", req.Id, req.Offset, n)
+	logrus.Tracef("Write sessionId=%s, Offset=%d, n=%d", req.Id, req.Offset, n)
 	rsp.Size = int64(n)
 	return nil
 }
+
+func (h *handler) WriteStream(ctx context.Context, stream proto.File_WriteStreamStream) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.Header == nil {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "the first message must contain the header")
+	}
+	header := req.Header
+	path, err := h.authorize(ctx, "WriteStream", WriteAccess, header.Filename)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(header.Mode) & os.ModePerm
+	if mode == 0 {
+		mode = h.createMode(path)
+	}
+	if fi, err := h.fs.Stat(path); err == nil && fi.IsDir() {
+		return newError(proto.ErrorCode_IS_DIRECTORY, "%s is a directory", header.Filename)
+	}
+	// the stream is written to a hidden temporary file which only replaces
+	// path once it is complete, like the atomic Create
+	dir, base := filepath.Split(path)
+	file, err := afero.TempFile(h.fs, dir, "."+base+".")
+	if err != nil {
+		return h.fsError(err)
+	}
+	temp := file.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			file.Close()
+			h.fs.Remove(temp)
+		}
+	}()
+
+	hash := sha256.New()
+	var size int64
+	for {
+		if len(req.Data) > 0 {
+			n, err := file.Write(req.Data)
+			if err != nil {
+				return h.fsError(err)
+			}
+			hash.Write(req.Data[:n])
+			size += int64(n)
+		}
+		if req.Eof {
+			break
+		}
+		if req, err = stream.Recv(); err != nil {
+			if err == io.EOF {
+				return newError(proto.ErrorCode_INVALID_ARGUMENT, "stream closed before the end of %s", header.Filename)
+			}
+			return err
+		}
+	}
+	if header.Size > 0 && size != header.Size {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "expected %d bytes, received %d", header.Size, size)
+	}
+	if err := file.Close(); err != nil {
+		return h.fsError(err)
+	}
+	committed = true
+	if err := h.fs.Chmod(temp, mode); err != nil {
+		h.fs.Remove(temp)
+		return h.fsError(err)
+	}
+	if err := h.fs.Rename(temp, path); err != nil {
+		h.fs.Remove(temp)
+		return h.fsError(err)
+	}
+
+	logrus.Tracef("WriteStream %s, n=%d", header.Filename, size)
+
+	return stream.SendMsg(&proto.WriteStreamResponse{
+		Size:     size,
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+	})
+}
+
+func (h *handler) Remove(ctx context.Context, req *proto.RemoveRequest, rsp *proto.RemoveResponse) error {
+	path, err := h.authorize(ctx, "Remove", WriteAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	if h.isRoot(path) {
+		return newError(proto.ErrorCode_PERMISSION_DENIED, "cannot remove the served directory")
+	}
+	if err := h.fs.Remove(path); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Remove %s", req.Filename)
+	return nil
+}
+
+func (h *handler) RemoveAll(ctx context.Context, req *proto.RemoveAllRequest, rsp *proto.RemoveAllResponse) error {
+	path, err := h.authorize(ctx, "RemoveAll", WriteAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	if h.isRoot(path) {
+		return newError(proto.ErrorCode_PERMISSION_DENIED, "cannot remove the served directory")
+	}
+	if err := h.fs.RemoveAll(path); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("RemoveAll %s", req.Filename)
+	return nil
+}
+
+func (h *handler) Rename(ctx context.Context, req *proto.RenameRequest, rsp *proto.RenameResponse) error {
+	oldpath, err := h.authorize(ctx, "Rename", WriteAccess, req.Oldname)
+	if err != nil {
+		return err
+	}
+	newpath, err := h.authorize(ctx, "Rename", WriteAccess, req.Newname)
+	if err != nil {
+		return err
+	}
+	if h.isRoot(oldpath) || h.isRoot(newpath) {
+		return newError(proto.ErrorCode_PERMISSION_DENIED, "cannot rename the served directory")
+	}
+	if err := h.fs.Rename(oldpath, newpath); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Rename %s to %s", req.Oldname, req.Newname)
+	return nil
+}
+
+func (h *handler) Mkdir(ctx context.Context, req *proto.MkdirRequest, rsp *proto.MkdirResponse) error {
+	path, err := h.authorize(ctx, "Mkdir", WriteAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	if err := h.fs.Mkdir(path, os.FileMode(req.Perm)&os.ModePerm); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("Mkdir %s", req.Filename)
+	return nil
+}
+
+func (h *handler) MkdirAll(ctx context.Context, req *proto.MkdirAllRequest, rsp *proto.MkdirAllResponse) error {
+	path, err := h.authorize(ctx, "MkdirAll", WriteAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	if err := h.fs.MkdirAll(path, os.FileMode(req.Perm)&os.ModePerm); err != nil {
+		return h.fsError(err)
+	}
+	logrus.Tracef("MkdirAll %s", req.Filename)
+	return nil
+}
+
+// isRoot reports whether path is the served directory itself
+func (h *handler) isRoot(path string) bool {
+	return filepath.Clean(path) == filepath.Clean(h.dir)
+}
+
+// fileInfo returns the proto.FileInfo of fi, listed as name
+func fileInfo(name string, fi os.FileInfo) *proto.FileInfo {
+	return &proto.FileInfo{
+		Name:         name,
+		Size:         fi.Size(),
+		Mode:         uint32(fi.Mode()),
+		LastModified: fi.ModTime().Unix(),
+		ModTime:      fi.ModTime().UnixNano(),
+	}
+}
+
+// lstat returns the FileInfo of path, which describes the symlink itself
+// when the fs supports it
+func (h *handler) lstat(path string) (os.FileInfo, error) {
+	if lstater, ok := h.fs.(afero.Lstater); ok {
+		fi, _, err := lstater.LstatIfPossible(path)
+		return fi, err
+	}
+	return h.fs.Stat(path)
+}
+
+// linkTarget returns the target of the symlink at path, or an empty string if
+// it is not a symlink. Symlinks can only be read on the os filesystem. Like
+// the paths of the errors, only the base name of a target outside of the
+// served directory is returned.
+func (h *handler) linkTarget(path string) string {
+	if _, ok := h.fs.(*afero.OsFs); !ok {
+		return ""
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	resolved := target
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(filepath.Dir(path), target)
+	}
+	if !within(h.dir, resolved) {
+		return filepath.Base(target)
+	}
+	if filepath.IsAbs(target) {
+		target = string(filepath.Separator) + strings.TrimPrefix(target[len(h.dir):], string(filepath.Separator))
+	}
+	return filepath.ToSlash(target)
+}
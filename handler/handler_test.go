@@ -0,0 +1,944 @@
+package handler
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	"github.com/micro/go-micro/metadata"
+	"github.com/spf13/afero"
+	"golang.org/x/net/context"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+func TestPathTraversal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/etc/passwd", []byte("root"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/srv/a/file.txt", []byte("file"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{
+		"../etc/passwd",
+		"a/../../etc/passwd",
+		"..",
+		"/etc/passwd",
+		"/",
+		"a/../../srv/a/file.txt",
+	} {
+		err := h.Stat(context.TODO(), &proto.StatRequest{Filename: name}, &proto.StatResponse{})
+		assertCode(t, name, err, http.StatusForbidden)
+		err = h.Open(context.TODO(), &proto.OpenRequest{Filename: name}, &proto.OpenResponse{})
+		assertCode(t, name, err, http.StatusForbidden)
+		err = h.Create(context.TODO(), &proto.CreateRequest{Filename: name}, &proto.CreateResponse{})
+		assertCode(t, name, err, http.StatusForbidden)
+		err = h.Rename(context.TODO(), &proto.RenameRequest{Oldname: "a/file.txt", Newname: name}, &proto.RenameResponse{})
+		assertCode(t, name, err, http.StatusForbidden)
+	}
+
+	for _, name := range []string{"a/file.txt", "a/../a/file.txt", "./a/file.txt"} {
+		if err := h.Stat(context.TODO(), &proto.StatRequest{Filename: name}, &proto.StatResponse{}); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestSymlinkCheck(t *testing.T) {
+	td, err := ioutil.TempDir("", "go-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	root := filepath.Join(td, "root")
+	outside := filepath.Join(td, "outside")
+	for _, v := range []string{root, outside, filepath.Join(root, "dir")} {
+		if err := os.Mkdir(v, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"escape":     outside,
+		"escapefile": filepath.Join(outside, "secret"),
+		"dangling":   filepath.Join(outside, "missing"),
+		"inner":      filepath.Join(root, "dir"),
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Skip("symlinks not supported: ", err)
+		}
+	}
+
+	h, err := NewHandler(root, afero.NewOsFs(), WithSymlinkCheck(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"escape/secret", "escapefile", "escape"} {
+		err := h.Open(context.TODO(), &proto.OpenRequest{Filename: name}, &proto.OpenResponse{})
+		assertCode(t, name, err, http.StatusForbidden)
+	}
+	err = h.Create(context.TODO(), &proto.CreateRequest{Filename: "dangling"}, &proto.CreateResponse{})
+	assertCode(t, "dangling", err, http.StatusForbidden)
+	if _, err := os.Stat(filepath.Join(outside, "missing")); !os.IsNotExist(err) {
+		t.Error("file created outside of the served directory")
+	}
+	if err := h.Create(context.TODO(), &proto.CreateRequest{Filename: "inner/file"}, &proto.CreateResponse{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// without the check, symlinks are followed
+	h, err = NewHandler(root, afero.NewOsFs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Stat(context.TODO(), &proto.StatRequest{Filename: "escape/secret"}, &proto.StatResponse{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestStat(t *testing.T) {
+	td, err := ioutil.TempDir("", "go-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	if err := os.Mkdir(filepath.Join(td, "dir"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(td, "dir", "file"), []byte("file"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Unix(1546398245, 123456789)
+	if err := os.Chtimes(filepath.Join(td, "dir", "file"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler(td, afero.NewOsFs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp := &proto.StatResponse{}
+	if err := h.Stat(context.TODO(), &proto.StatRequest{Filename: "dir/file"}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if os.FileMode(rsp.Mode) != 0640 || rsp.Size != 4 || rsp.ModTime != mtime.UnixNano() || rsp.LinkTarget != "" {
+		t.Errorf("unexpected stat %v", rsp)
+	}
+	if runtime.GOOS == "linux" && (rsp.Ino == 0 || rsp.Nlink != 1 || rsp.Uid != uint32(os.Getuid())) {
+		t.Errorf("unexpected identity %v", rsp)
+	}
+
+	if err := os.Symlink(filepath.Join(td, "dir"), filepath.Join(td, "link")); err != nil {
+		t.Skip("symlinks not supported: ", err)
+	}
+	rsp = &proto.StatResponse{}
+	if err := h.Stat(context.TODO(), &proto.StatRequest{Filename: "link"}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if os.FileMode(rsp.Mode)&os.ModeSymlink == 0 || rsp.LinkTarget != "/dir" {
+		t.Errorf("unexpected stat %v", rsp)
+	}
+
+	// the targets outside of the served directory are not disclosed
+	for name, target := range map[string]string{
+		"abs": filepath.Join(filepath.Dir(td), "outside", "secret"),
+		"rel": filepath.Join("..", "..", "outside", "secret"),
+	} {
+		if err := os.Symlink(target, filepath.Join(td, "dir", name)); err != nil {
+			t.Fatal(err)
+		}
+		rsp = &proto.StatResponse{}
+		if err := h.Stat(context.TODO(), &proto.StatRequest{Filename: "dir/" + name}, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if rsp.LinkTarget != "secret" {
+			t.Errorf("%s: unexpected link target %q", name, rsp.LinkTarget)
+		}
+	}
+}
+
+func TestSessionReaping(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/file.txt", []byte("file"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs, WithMaxSessions(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := h.(*handler).session
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		rsp := &proto.OpenResponse{}
+		if err := h.Open(context.TODO(), &proto.OpenRequest{Filename: "file.txt"}, rsp); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, rsp.Id)
+	}
+	err = h.Open(context.TODO(), &proto.OpenRequest{Filename: "file.txt"}, &proto.OpenResponse{})
+	assertCode(t, "max sessions", err, http.StatusTooManyRequests)
+
+	// the refused sessions do not touch the files
+	err = h.Create(context.TODO(), &proto.CreateRequest{Filename: "file.txt"}, &proto.CreateResponse{})
+	assertCode(t, "max sessions create", err, http.StatusTooManyRequests)
+	err = h.Create(context.TODO(), &proto.CreateRequest{Filename: "new.txt"}, &proto.CreateResponse{})
+	assertCode(t, "max sessions create new", err, http.StatusTooManyRequests)
+	flags := uint32(proto.OpenFlag_O_WRONLY | proto.OpenFlag_O_TRUNC)
+	err = h.OpenFile(context.TODO(), &proto.OpenFileRequest{Filename: "file.txt", Flags: flags}, &proto.OpenFileResponse{})
+	assertCode(t, "max sessions truncate", err, http.StatusTooManyRequests)
+	if b, err := afero.ReadFile(fs, "/srv/file.txt"); err != nil || string(b) != "file" {
+		t.Errorf("refused session changed the file: %q (%v)", b, err)
+	}
+	if _, err := fs.Stat("/srv/new.txt"); !os.IsNotExist(err) {
+		t.Errorf("refused session created the file (%v)", err)
+	}
+
+	// only the second session is used after the first one
+	s.files[ids[0]].lastAccess = time.Now().Add(-time.Hour)
+	if reaped := s.Reap(time.Now(), time.Minute); len(reaped) != 1 || reaped[0] != ids[0] {
+		t.Fatalf("got %v, expected %s to be reaped", reaped, ids[0])
+	}
+	if s.Len() != 1 {
+		t.Errorf("got %d sessions, expected 1", s.Len())
+	}
+
+	err = h.Read(context.TODO(), &proto.ReadRequest{Id: ids[0], Size: 4}, &proto.ReadResponse{})
+	assertCode(t, "read", err, http.StatusGone)
+	err = h.Write(context.TODO(), &proto.WriteRequest{Id: ids[0], Data: []byte("x")}, &proto.WriteResponse{})
+	assertCode(t, "write", err, http.StatusGone)
+	if err := h.Read(context.TODO(), &proto.ReadRequest{Id: ids[1], Size: 4}, &proto.ReadResponse{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestSessionOwnership(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/file.txt", []byte("file"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := metadata.NewContext(context.TODO(), metadata.Metadata{CallerMetadataKey: "alice"})
+	bob := metadata.NewContext(context.TODO(), metadata.Metadata{"caller-id": "bob"})
+
+	ids := map[string]bool{}
+	var id string
+	for i := 0; i < 10; i++ {
+		rsp := &proto.OpenResponse{}
+		if err := h.Open(alice, &proto.OpenRequest{Filename: "file.txt"}, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if len(rsp.Id) != 32 || ids[rsp.Id] {
+			t.Fatalf("unexpected session id %q", rsp.Id)
+		}
+		ids[rsp.Id] = true
+		id = rsp.Id
+	}
+
+	err = h.Read(bob, &proto.ReadRequest{Id: id, Size: 4}, &proto.ReadResponse{})
+	assertCode(t, "read", err, http.StatusForbidden)
+	err = h.Write(context.TODO(), &proto.WriteRequest{Id: id, Data: []byte("x")}, &proto.WriteResponse{})
+	assertCode(t, "write", err, http.StatusForbidden)
+	err = h.Close(bob, &proto.CloseRequest{Id: id}, &proto.CloseResponse{})
+	assertCode(t, "close", err, http.StatusForbidden)
+
+	if err := h.Read(alice, &proto.ReadRequest{Id: id, Size: 4}, &proto.ReadResponse{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := h.Close(alice, &proto.CloseRequest{Id: id}, &proto.CloseResponse{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	if err := afero.WriteFile(fs, "/srv/file.txt", data, 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp := &proto.ChecksumResponse{}
+	if err := h.Checksum(context.TODO(), &proto.ChecksumRequest{Filename: "file.txt", BlockSize: 300, Blocks: true}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	if rsp.Size != int64(len(data)) || rsp.Checksum != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected checksum %v", rsp)
+	}
+	if len(rsp.Blocks) != 4 || rsp.Blocks[3] != crc32.Checksum(data[900:], castagnoli) {
+		t.Errorf("unexpected block checksums %v", rsp.Blocks)
+	}
+
+	orsp := &proto.CreateResponse{}
+	if err := h.Create(context.TODO(), &proto.CreateRequest{Filename: "new.txt"}, orsp); err != nil {
+		t.Fatal(err)
+	}
+	err = h.Write(context.TODO(), &proto.WriteRequest{Id: orsp.Id, Data: []byte("data"), Checksum: blockChecksum([]byte("date"))}, &proto.WriteResponse{})
+	assertCode(t, "write", err, http.StatusUnprocessableEntity)
+	if err := h.Write(context.TODO(), &proto.WriteRequest{Id: orsp.Id, Data: []byte("data"), Checksum: blockChecksum([]byte("data"))}, &proto.WriteResponse{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestAtomicCreate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/file.txt", []byte("old"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	create := func() string {
+		rsp := &proto.CreateResponse{}
+		if err := h.Create(ctx, &proto.CreateRequest{Filename: "file.txt", Atomic: true}, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Write(ctx, &proto.WriteRequest{Id: rsp.Id, Data: []byte("new")}, &proto.WriteResponse{}); err != nil {
+			t.Fatal(err)
+		}
+		if b, _ := afero.ReadFile(fs, "/srv/file.txt"); string(b) != "old" {
+			t.Fatalf("partial content visible: %q", b)
+		}
+		return rsp.Id
+	}
+	temps := func() int {
+		infos, err := afero.ReadDir(fs, "/srv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(infos) - 1
+	}
+
+	if err := h.Close(ctx, &proto.CloseRequest{Id: create(), Abort: true}, &proto.CloseResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := afero.ReadFile(fs, "/srv/file.txt"); string(b) != "old" || temps() != 0 {
+		t.Fatalf("aborted content published: %q, %d temp files", b, temps())
+	}
+
+	create()
+	h.(*handler).session.Reap(time.Now().Add(time.Hour), time.Minute)
+	if b, _ := afero.ReadFile(fs, "/srv/file.txt"); string(b) != "old" || temps() != 0 {
+		t.Fatalf("reaped content published: %q, %d temp files", b, temps())
+	}
+
+	if err := fs.Chmod("/srv/file.txt", 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Close(ctx, &proto.CloseRequest{Id: create()}, &proto.CloseResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := afero.ReadFile(fs, "/srv/file.txt"); string(b) != "new" || temps() != 0 {
+		t.Fatalf("unexpected content %q, %d temp files", b, temps())
+	}
+	if fi, err := fs.Stat("/srv/file.txt"); err != nil || fi.Mode().Perm() != 0600 {
+		t.Errorf("replaced file mode not kept (%v)", err)
+	}
+
+	// the new files are not writable by everyone
+	rsp := &proto.CreateResponse{}
+	if err := h.Create(ctx, &proto.CreateRequest{Filename: "other.txt", Atomic: true}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Close(ctx, &proto.CloseRequest{Id: rsp.Id}, &proto.CloseResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := fs.Stat("/srv/other.txt"); err != nil || fi.Mode().Perm() != defaultFileMode {
+		t.Errorf("unexpected mode of a new file (%v)", err)
+	}
+}
+
+// writeStream is a WriteStream stream receiving reqs
+type writeStream struct {
+	reqs []*proto.WriteStreamRequest
+	rsp  interface{}
+}
+
+func (s *writeStream) SendMsg(v interface{}) error { s.rsp = v; return nil }
+func (s *writeStream) RecvMsg(v interface{}) error { return nil }
+func (s *writeStream) Close() error                { return nil }
+
+func (s *writeStream) Recv() (*proto.WriteStreamRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func TestAtomicWriteStream(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/srv/file.txt", []byte("old"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	header := func(size int64) *proto.WriteStreamRequest {
+		return &proto.WriteStreamRequest{Header: &proto.WriteStreamHeader{Filename: "file.txt", Size: size}}
+	}
+	assertUnchanged := func(name string) {
+		if b, _ := afero.ReadFile(fs, "/srv/file.txt"); string(b) != "old" {
+			t.Fatalf("%s: partial content published: %q", name, b)
+		}
+		if infos, _ := afero.ReadDir(fs, "/srv"); len(infos) != 1 {
+			t.Fatalf("%s: %d temp files left", name, len(infos)-1)
+		}
+	}
+
+	err = h.WriteStream(ctx, &writeStream{reqs: []*proto.WriteStreamRequest{header(10), {Data: []byte("new"), Eof: true}}})
+	assertStatus(t, "size mismatch", err, proto.ErrorCode_INVALID_ARGUMENT)
+	assertUnchanged("size mismatch")
+
+	err = h.WriteStream(ctx, &writeStream{reqs: []*proto.WriteStreamRequest{header(0), {Data: []byte("new")}}})
+	assertStatus(t, "interrupted", err, proto.ErrorCode_INVALID_ARGUMENT)
+	assertUnchanged("interrupted")
+
+	if err := fs.Chmod("/srv/file.txt", 0600); err != nil {
+		t.Fatal(err)
+	}
+	stream := &writeStream{reqs: []*proto.WriteStreamRequest{header(3), {Data: []byte("new"), Eof: true}}}
+	if err := h.WriteStream(ctx, stream); err != nil {
+		t.Fatal(err)
+	}
+	if rsp, ok := stream.rsp.(*proto.WriteStreamResponse); !ok || rsp.Size != 3 {
+		t.Fatalf("unexpected response %v", stream.rsp)
+	}
+	if b, _ := afero.ReadFile(fs, "/srv/file.txt"); string(b) != "new" {
+		t.Fatalf("unexpected content %q", b)
+	}
+	if fi, err := fs.Stat("/srv/file.txt"); err != nil || fi.Mode().Perm() != 0600 {
+		t.Errorf("replaced file mode not kept (%v)", err)
+	}
+	if infos, _ := afero.ReadDir(fs, "/srv"); len(infos) != 1 {
+		t.Fatalf("%d temp files left", len(infos)-1)
+	}
+}
+
+func TestSessionAttributes(t *testing.T) {
+	td, err := ioutil.TempDir("", "go-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	if err := ioutil.WriteFile(filepath.Join(td, "file"), []byte("file"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler(td, afero.NewOsFs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	ro := &proto.OpenResponse{}
+	if err := h.Open(ctx, &proto.OpenRequest{Filename: "file"}, ro); err != nil {
+		t.Fatal(err)
+	}
+	err = h.Chmod(ctx, &proto.ChmodRequest{Id: ro.Id, Mode: 0777}, &proto.ChmodResponse{})
+	assertStatus(t, "chmod read only session", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.Chtimes(ctx, &proto.ChtimesRequest{Id: ro.Id}, &proto.ChtimesResponse{})
+	assertStatus(t, "chtimes read only session", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.Chown(ctx, &proto.ChownRequest{Id: ro.Id, Uid: -1, Gid: -1}, &proto.ChownResponse{})
+	assertStatus(t, "chown read only session", err, proto.ErrorCode_PERMISSION_DENIED)
+	if fi, err := os.Stat(filepath.Join(td, "file")); err != nil || fi.Mode().Perm() != 0640 {
+		t.Fatalf("mode changed through a read only session (%v)", err)
+	}
+
+	rw := &proto.OpenFileResponse{}
+	if err := h.OpenFile(ctx, &proto.OpenFileRequest{Filename: "file", Flags: uint32(proto.OpenFlag_O_RDWR)}, rw); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Chmod(ctx, &proto.ChmodRequest{Id: rw.Id, Mode: 0600}, &proto.ChmodResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(td, "file"), filepath.Join(td, "moved")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(td, "file"), []byte("other"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	err = h.Chmod(ctx, &proto.ChmodRequest{Id: rw.Id, Mode: 0777}, &proto.ChmodResponse{})
+	assertStatus(t, "chmod moved session file", err, proto.ErrorCode_NOT_FOUND)
+	if fi, err := os.Stat(filepath.Join(td, "file")); err != nil || fi.Mode().Perm() != 0640 {
+		t.Fatalf("mode of another file changed (%v)", err)
+	}
+}
+
+func TestUploadSession(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs, WithUploadTTL(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := metadata.NewContext(context.TODO(), metadata.Metadata{CallerMetadataKey: "alice"})
+	bob := metadata.NewContext(context.TODO(), metadata.Metadata{CallerMetadataKey: "bob"})
+
+	data := []byte("0123456789")
+	brsp := &proto.UploadBeginResponse{}
+	if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Filename: "dir/file.txt", Size: int64(len(data))}, brsp); err != nil {
+		t.Fatal(err)
+	}
+	id := brsp.Id
+
+	for _, r := range [][2]int{{6, 10}, {0, 3}} {
+		req := &proto.UploadAppendRequest{Id: id, Offset: int64(r[0]), Data: data[r[0]:r[1]]}
+		if err := h.UploadAppend(alice, req, &proto.UploadAppendResponse{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srsp := &proto.UploadStatusResponse{}
+	if err := h.UploadStatus(alice, &proto.UploadStatusRequest{Id: id}, srsp); err != nil {
+		t.Fatal(err)
+	}
+	if len(srsp.Ranges) != 2 || srsp.Ranges[0].Length != 3 || srsp.Ranges[1].Offset != 6 {
+		t.Fatalf("unexpected ranges %v", srsp.Ranges)
+	}
+
+	err = h.UploadCommit(alice, &proto.UploadCommitRequest{Id: id}, &proto.UploadCommitResponse{})
+	assertCode(t, "incomplete commit", err, http.StatusBadRequest)
+	if _, err := fs.Stat("/srv/dir/file.txt"); !os.IsNotExist(err) {
+		t.Fatal("incomplete upload published")
+	}
+	err = h.UploadAppend(alice, &proto.UploadAppendRequest{Id: id, Offset: 8, Data: data}, &proto.UploadAppendResponse{})
+	assertCode(t, "append outside", err, http.StatusBadRequest)
+	err = h.UploadAppend(bob, &proto.UploadAppendRequest{Id: id, Offset: 3, Data: data[3:6]}, &proto.UploadAppendResponse{})
+	assertCode(t, "append by another caller", err, http.StatusForbidden)
+	err = h.Stat(alice, &proto.StatRequest{Filename: ".uploads/" + id}, &proto.StatResponse{})
+	assertCode(t, "stat reserved", err, http.StatusForbidden)
+
+	if err := h.UploadAppend(alice, &proto.UploadAppendRequest{Id: id, Offset: 3, Data: data[3:6]}, &proto.UploadAppendResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	err = h.UploadCommit(alice, &proto.UploadCommitRequest{Id: id, Checksum: "bad"}, &proto.UploadCommitResponse{})
+	assertCode(t, "commit checksum", err, http.StatusUnprocessableEntity)
+	sum := sha256.Sum256(data)
+	if err := h.UploadCommit(alice, &proto.UploadCommitRequest{Id: id, Checksum: hex.EncodeToString(sum[:])}, &proto.UploadCommitResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/file.txt"); err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("unexpected content %q (%v)", b, err)
+	}
+	if fi, err := fs.Stat("/srv/dir/file.txt"); err != nil || fi.Mode().Perm() != defaultFileMode {
+		t.Errorf("unexpected mode of an upload without mode (%v)", err)
+	}
+
+	// a lost commit response is recovered by the status or a retried commit
+	srsp = &proto.UploadStatusResponse{}
+	if err := h.UploadStatus(alice, &proto.UploadStatusRequest{Id: id}, srsp); err != nil {
+		t.Fatal(err)
+	}
+	if len(srsp.Ranges) != 1 || srsp.Ranges[0].Offset != 0 || srsp.Ranges[0].Length != int64(len(data)) {
+		t.Fatalf("committed upload not complete: %v", srsp.Ranges)
+	}
+	crsp := &proto.UploadCommitResponse{}
+	if err := h.UploadCommit(alice, &proto.UploadCommitRequest{Id: id}, crsp); err != nil {
+		t.Fatal(err)
+	}
+	if crsp.Size != int64(len(data)) || crsp.Checksum != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected retried commit %v", crsp)
+	}
+	err = h.UploadAppend(alice, &proto.UploadAppendRequest{Id: id, Offset: 0, Data: data[:1]}, &proto.UploadAppendResponse{})
+	assertCode(t, "append after commit", err, http.StatusBadRequest)
+
+	lrsp := &proto.ListResponse{}
+	if err := h.List(alice, &proto.ListRequest{Recursive: true}, lrsp); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range lrsp.Files {
+		if strings.HasPrefix(f.Name, ".uploads") {
+			t.Errorf("reserved directory listed: %s", f.Name)
+		}
+	}
+
+	if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Filename: "aborted.txt", Size: 1}, brsp); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.UploadAbort(alice, &proto.UploadAbortRequest{Id: brsp.Id}, &proto.UploadAbortResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 1 {
+		t.Fatalf("upload files left after abort: %d (%v)", len(infos)-1, err)
+	}
+
+	if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Filename: "abandoned.txt", Size: 1}, brsp); err != nil {
+		t.Fatal(err)
+	}
+	if reaped := h.(*handler).reapUploads(time.Now()); len(reaped) != 0 {
+		t.Fatalf("active upload reaped: %v", reaped)
+	}
+	if reaped := h.(*handler).reapUploads(time.Now().Add(90 * time.Minute)); len(reaped) != 1 || reaped[0] != id {
+		t.Fatalf("committed upload not reaped: %v", reaped)
+	}
+	if reaped := h.(*handler).reapUploads(time.Now().Add(3 * time.Hour)); len(reaped) != 1 || reaped[0] != brsp.Id {
+		t.Fatalf("abandoned upload not reaped: %v", reaped)
+	}
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 0 {
+		t.Fatalf("upload files left after reaping: %d (%v)", len(infos), err)
+	}
+	err = h.UploadStatus(alice, &proto.UploadStatusRequest{Id: brsp.Id}, &proto.UploadStatusResponse{})
+	assertCode(t, "status after reaping", err, http.StatusNotFound)
+}
+
+func TestUploadParts(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := fs.MkdirAll("/srv", 0755); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler("/srv", fs, WithUploadTTL(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := metadata.NewContext(context.TODO(), metadata.Metadata{CallerMetadataKey: "alice"})
+	bob := metadata.NewContext(context.TODO(), metadata.Metadata{CallerMetadataKey: "bob"})
+
+	prsp := &proto.UploadBeginResponse{}
+	if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Filename: "dir/multi.bin"}, prsp); err != nil {
+		t.Fatal(err)
+	}
+	parent := prsp.Id
+	err = h.UploadBegin(bob, &proto.UploadBeginRequest{Parent: parent, Part: 1, Size: 1}, &proto.UploadBeginResponse{})
+	assertCode(t, "part by another caller", err, http.StatusForbidden)
+	err = h.UploadBegin(alice, &proto.UploadBeginRequest{Parent: parent, Size: 1}, &proto.UploadBeginResponse{})
+	assertCode(t, "part 0", err, http.StatusBadRequest)
+
+	// sends a part and returns its MD5
+	part := func(n int32, data string) string {
+		brsp := &proto.UploadBeginResponse{}
+		if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Parent: parent, Part: n, Size: int64(len(data))}, brsp); err != nil {
+			t.Fatal(err)
+		}
+		if err := h.UploadAppend(alice, &proto.UploadAppendRequest{Id: brsp.Id, Data: []byte(data)}, &proto.UploadAppendResponse{}); err != nil {
+			t.Fatal(err)
+		}
+		crsp := &proto.UploadCommitResponse{}
+		if err := h.UploadCommit(alice, &proto.UploadCommitRequest{Id: brsp.Id}, crsp); err != nil {
+			t.Fatal(err)
+		}
+		if sum := md5.Sum([]byte(data)); crsp.Md5 != hex.EncodeToString(sum[:]) {
+			t.Fatalf("unexpected part MD5 %s", crsp.Md5)
+		}
+		return crsp.Md5
+	}
+	part(1, "first")
+	one, two := part(1, "one"), part(2, "two")
+	if _, err := fs.Stat("/srv/dir/multi.bin"); !os.IsNotExist(err) {
+		t.Fatal("part published")
+	}
+	// the replaced part is removed
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 6 {
+		t.Fatalf("unexpected upload files: %d (%v)", len(infos), err)
+	}
+
+	err = h.UploadCommit(alice, &proto.UploadCommitRequest{Id: parent, Parts: []*proto.UploadPart{{Part: 1, Md5: two}, {Part: 2, Md5: two}}}, &proto.UploadCommitResponse{})
+	assertCode(t, "commit with a wrong MD5", err, http.StatusUnprocessableEntity)
+	err = h.UploadCommit(alice, &proto.UploadCommitRequest{Id: parent, Parts: []*proto.UploadPart{{Part: 1, Md5: one}, {Part: 3, Md5: two}}}, &proto.UploadCommitResponse{})
+	assertCode(t, "commit with a missing part", err, http.StatusNotFound)
+	err = h.UploadCommit(alice, &proto.UploadCommitRequest{Id: parent, Parts: []*proto.UploadPart{{Part: 2, Md5: two}, {Part: 1, Md5: one}}}, &proto.UploadCommitResponse{})
+	assertCode(t, "commit out of order", err, http.StatusBadRequest)
+
+	crsp := &proto.UploadCommitResponse{}
+	if err := h.UploadCommit(alice, &proto.UploadCommitRequest{Id: parent, Parts: []*proto.UploadPart{{Part: 1, Md5: one}, {Part: 2, Md5: strings.ToUpper(two)}}}, crsp); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/dir/multi.bin"); err != nil || string(b) != "onetwo" || crsp.Size != 6 {
+		t.Fatalf("unexpected content %q (%v)", b, err)
+	}
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 1 {
+		t.Fatalf("parts left after commit: %d (%v)", len(infos)-1, err)
+	}
+	err = h.UploadBegin(alice, &proto.UploadBeginRequest{Parent: parent, Part: 3, Size: 1}, &proto.UploadBeginResponse{})
+	assertCode(t, "part of a committed upload", err, http.StatusNotFound)
+
+	// the parts are aborted with their upload
+	if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Filename: "aborted.bin"}, prsp); err != nil {
+		t.Fatal(err)
+	}
+	parent = prsp.Id
+	part(1, "one")
+	if err := h.UploadAbort(alice, &proto.UploadAbortRequest{Id: parent}, &proto.UploadAbortResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if infos, err := afero.ReadDir(fs, "/srv/.uploads"); err != nil || len(infos) != 1 {
+		t.Fatalf("parts left after abort: %d (%v)", len(infos)-1, err)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	td, err := ioutil.TempDir("", "go-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	if err := os.MkdirAll(filepath.Join(td, "dir", "sub"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(td, "file"), []byte("file"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHandler(td, afero.NewOsFs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	err = h.Stat(ctx, &proto.StatRequest{Filename: "missing"}, &proto.StatResponse{})
+	assertStatus(t, "stat missing", err, proto.ErrorCode_NOT_FOUND)
+	if strings.Contains(err.Error(), td) {
+		t.Errorf("served directory leaked in %v", err)
+	}
+	err = h.Stat(ctx, &proto.StatRequest{Filename: "../file"}, &proto.StatResponse{})
+	assertStatus(t, "stat outside", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.Mkdir(ctx, &proto.MkdirRequest{Filename: "dir"}, &proto.MkdirResponse{})
+	assertStatus(t, "mkdir existing", err, proto.ErrorCode_EXISTS)
+	err = h.Mkdir(ctx, &proto.MkdirRequest{Filename: "file/dir"}, &proto.MkdirResponse{})
+	assertStatus(t, "mkdir in a file", err, proto.ErrorCode_NOT_DIRECTORY)
+	err = h.Create(ctx, &proto.CreateRequest{Filename: "dir"}, &proto.CreateResponse{})
+	assertStatus(t, "create a directory", err, proto.ErrorCode_IS_DIRECTORY)
+	err = h.Remove(ctx, &proto.RemoveRequest{Filename: "dir"}, &proto.RemoveResponse{})
+	assertStatus(t, "remove non empty", err, proto.ErrorCode_NOT_EMPTY)
+	err = h.Read(ctx, &proto.ReadRequest{Id: "unknown", Size: 1}, &proto.ReadResponse{})
+	assertStatus(t, "read without session", err, proto.ErrorCode_SESSION_NOT_FOUND)
+	err = h.Write(ctx, &proto.WriteRequest{Id: "unknown", Data: []byte("x")}, &proto.WriteResponse{})
+	assertStatus(t, "write without session", err, proto.ErrorCode_SESSION_NOT_FOUND)
+	err = h.UploadStatus(ctx, &proto.UploadStatusRequest{Id: "0123456789abcdef0123456789abcdef"}, &proto.UploadStatusResponse{})
+	assertStatus(t, "unknown upload", err, proto.ErrorCode_SESSION_NOT_FOUND)
+}
+
+func TestTokenAuthorizer(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, name := range []string{"/srv/private.txt", "/srv/public/a.txt", "/srv/publicity.txt"} {
+		if err := afero.WriteFile(fs, name, []byte("file"), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h, err := NewHandler("/srv", fs, WithAuthorizer(NewTokenAuthorizer(
+		map[string]string{"alice-token": "alice", "bob-token": "bob", "carol-token": "carol", "anonymous-token": ""},
+		Rule{Subjects: []string{"alice", "carol"}, Access: WriteAccess},
+		Rule{Path: "public", Subjects: []string{"*"}, Access: ReadAccess},
+		Rule{Path: "/public/bob/", Subjects: []string{"bob"}, Access: WriteAccess},
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	as := func(token string) context.Context {
+		return metadata.NewContext(context.TODO(), metadata.Metadata{"authorization": "Bearer " + token})
+	}
+	alice, bob, carol := as("alice-token"), as("bob-token"), as("carol-token")
+
+	err = h.Stat(context.TODO(), &proto.StatRequest{Filename: "public/a.txt"}, &proto.StatResponse{})
+	assertStatus(t, "no token", err, proto.ErrorCode_UNAUTHENTICATED)
+	err = h.Stat(as("bob"), &proto.StatRequest{Filename: "public/a.txt"}, &proto.StatResponse{})
+	assertStatus(t, "unknown token", err, proto.ErrorCode_UNAUTHENTICATED)
+
+	if err := h.Stat(bob, &proto.StatRequest{Filename: "public/a.txt"}, &proto.StatResponse{}); err != nil {
+		t.Errorf("bob stat public: %v", err)
+	}
+	err = h.Stat(bob, &proto.StatRequest{Filename: "private.txt"}, &proto.StatResponse{})
+	assertStatus(t, "bob stat private", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.Open(bob, &proto.OpenRequest{Filename: "publicity.txt"}, &proto.OpenResponse{})
+	assertStatus(t, "bob open outside of the public directory", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.List(bob, &proto.ListRequest{Filename: "."}, &proto.ListResponse{})
+	assertStatus(t, "bob list the served directory", err, proto.ErrorCode_PERMISSION_DENIED)
+	if err := h.OpenFile(bob, &proto.OpenFileRequest{Filename: "public/a.txt"}, &proto.OpenFileResponse{}); err != nil {
+		t.Errorf("bob open public read only: %v", err)
+	}
+	err = h.OpenFile(bob, &proto.OpenFileRequest{Filename: "public/a.txt", Flags: uint32(proto.OpenFlag_O_WRONLY)}, &proto.OpenFileResponse{})
+	assertStatus(t, "bob open public for writing", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.Create(bob, &proto.CreateRequest{Filename: "public/b.txt"}, &proto.CreateResponse{})
+	assertStatus(t, "bob create public", err, proto.ErrorCode_PERMISSION_DENIED)
+	if err := h.MkdirAll(bob, &proto.MkdirAllRequest{Filename: "public/bob/dir"}, &proto.MkdirAllResponse{}); err != nil {
+		t.Errorf("bob mkdir in his directory: %v", err)
+	}
+	err = h.Rename(bob, &proto.RenameRequest{Oldname: "public/bob/dir", Newname: "dir"}, &proto.RenameResponse{})
+	assertStatus(t, "bob rename out of his directory", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.Chmod(bob, &proto.ChmodRequest{Filename: "public/a.txt", Mode: 0600}, &proto.ChmodResponse{})
+	assertStatus(t, "bob chmod public", err, proto.ErrorCode_PERMISSION_DENIED)
+
+	if err := h.Rename(alice, &proto.RenameRequest{Oldname: "public/bob/dir", Newname: "dir"}, &proto.RenameResponse{}); err != nil {
+		t.Errorf("alice rename: %v", err)
+	}
+	if err := h.Remove(alice, &proto.RemoveRequest{Filename: "private.txt"}, &proto.RemoveResponse{}); err != nil {
+		t.Errorf("alice remove: %v", err)
+	}
+
+	// the sessions and the uploads belong to the authenticated subject
+	orsp := &proto.OpenResponse{}
+	if err := h.Open(alice, &proto.OpenRequest{Filename: "public/a.txt"}, orsp); err != nil {
+		t.Fatal(err)
+	}
+	err = h.Read(carol, &proto.ReadRequest{Id: orsp.Id, Size: 4}, &proto.ReadResponse{})
+	assertStatus(t, "carol read the session of alice", err, proto.ErrorCode_PERMISSION_DENIED)
+	if err := h.Read(alice, &proto.ReadRequest{Id: orsp.Id, Size: 4}, &proto.ReadResponse{}); err != nil {
+		t.Errorf("alice read: %v", err)
+	}
+	anonymous := as("anonymous-token")
+	if err := h.Stat(anonymous, &proto.StatRequest{Filename: "public/a.txt"}, &proto.StatResponse{}); err != nil {
+		t.Errorf("anonymous stat public: %v", err)
+	}
+	err = h.Open(anonymous, &proto.OpenRequest{Filename: "public/a.txt"}, &proto.OpenResponse{})
+	assertStatus(t, "anonymous open", err, proto.ErrorCode_UNAUTHENTICATED)
+	err = h.Read(anonymous, &proto.ReadRequest{Id: orsp.Id, Size: 4}, &proto.ReadResponse{})
+	assertStatus(t, "anonymous read the session of alice", err, proto.ErrorCode_UNAUTHENTICATED)
+	brsp := &proto.UploadBeginResponse{}
+	if err := h.UploadBegin(alice, &proto.UploadBeginRequest{Filename: "upload.txt", Size: 4}, brsp); err != nil {
+		t.Fatal(err)
+	}
+	appendAs := func(ctx context.Context) error {
+		return h.UploadAppend(ctx, &proto.UploadAppendRequest{Id: brsp.Id, Data: []byte("data")}, &proto.UploadAppendResponse{})
+	}
+	assertStatus(t, "bob append to the upload of alice", appendAs(bob), proto.ErrorCode_PERMISSION_DENIED)
+	assertStatus(t, "carol append to the upload of alice", appendAs(carol), proto.ErrorCode_PERMISSION_DENIED)
+	err = h.UploadCommit(carol, &proto.UploadCommitRequest{Id: brsp.Id}, &proto.UploadCommitResponse{})
+	assertStatus(t, "carol commit the upload of alice", err, proto.ErrorCode_PERMISSION_DENIED)
+	err = h.UploadAbort(bob, &proto.UploadAbortRequest{Id: brsp.Id}, &proto.UploadAbortResponse{})
+	assertStatus(t, "bob abort the upload of alice", err, proto.ErrorCode_PERMISSION_DENIED)
+	if err := appendAs(alice); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.UploadCommit(alice, &proto.UploadCommitRequest{Id: brsp.Id}, &proto.UploadCommitResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := afero.ReadFile(fs, "/srv/upload.txt"); err != nil || string(b) != "data" {
+		t.Errorf("unexpected upload %q (%v)", b, err)
+	}
+}
+
+// signJWT returns a JSON Web Token of the claims signed with sign
+func signJWT(t *testing.T, alg string, claims map[string]interface{}, sign func([]byte) []byte) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func TestJWTAuthorizer(t *testing.T) {
+	secret := []byte("secret")
+	hs256 := func(b []byte) []byte {
+		h := hmac.New(sha256.New, secret)
+		h.Write(b)
+		return h.Sum(nil)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs256 := func(b []byte) []byte {
+		sum := sha256.Sum256(b)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	now := time.Now().Unix()
+	valid := map[string]interface{}{"sub": "alice", "exp": now + 60}
+	rules := []Rule{{Path: "alice", Subjects: []string{"alice"}, Access: WriteAccess}}
+	read := Operation{Method: "Open", Access: ReadAccess}
+
+	for name, test := range map[string]struct {
+		authorizer Authorizer
+		token      string
+		path       string
+		expected   error
+	}{
+		"hs256":            {NewHS256Authorizer(secret, rules...), signJWT(t, "HS256", valid, hs256), "alice/file", nil},
+		"rs256":            {NewRS256Authorizer(&key.PublicKey, rules...), signJWT(t, "RS256", valid, rs256), "alice/file", nil},
+		"other path":       {NewHS256Authorizer(secret, rules...), signJWT(t, "HS256", valid, hs256), "bob/file", ErrForbidden},
+		"other secret":     {NewHS256Authorizer([]byte("other"), rules...), signJWT(t, "HS256", valid, hs256), "alice/file", ErrUnauthenticated},
+		"other algorithm":  {NewRS256Authorizer(&key.PublicKey, rules...), signJWT(t, "HS256", valid, hs256), "alice/file", ErrUnauthenticated},
+		"none algorithm":   {NewHS256Authorizer(secret, rules...), signJWT(t, "none", valid, func([]byte) []byte { return nil }), "alice/file", ErrUnauthenticated},
+		"expired":          {NewHS256Authorizer(secret, rules...), signJWT(t, "HS256", map[string]interface{}{"sub": "alice", "exp": now - 60}, hs256), "alice/file", ErrUnauthenticated},
+		"not yet valid":    {NewHS256Authorizer(secret, rules...), signJWT(t, "HS256", map[string]interface{}{"sub": "alice", "nbf": now + 60}, hs256), "alice/file", ErrUnauthenticated},
+		"without subject":  {NewHS256Authorizer(secret, rules...), signJWT(t, "HS256", map[string]interface{}{"exp": now + 60}, hs256), "alice/file", ErrUnauthenticated},
+		"malformed":        {NewHS256Authorizer(secret, rules...), "a.b", "alice/file", ErrUnauthenticated},
+		"without rules":    {NewHS256Authorizer(secret), signJWT(t, "HS256", valid, hs256), "bob/file", nil},
+		"tampered payload": {NewHS256Authorizer(secret, rules...), tamper(signJWT(t, "HS256", map[string]interface{}{"sub": "bob"}, hs256)), "alice/file", ErrUnauthenticated},
+	} {
+		md := metadata.Metadata{AuthorizationMetadataKey: "Bearer " + test.token}
+		subject, err := test.authorizer.Authorize(md, read, test.path)
+		if err != test.expected {
+			t.Errorf("%s: got %v, expected %v", name, err, test.expected)
+		}
+		if err == nil && subject != "alice" {
+			t.Errorf("%s: got subject %q, expected alice", name, subject)
+		}
+	}
+}
+
+// tamper replaces the subject of a token by alice, keeping its signature
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice"}`))
+	return strings.Join(parts, ".")
+}
+
+func assertStatus(t *testing.T, name string, err error, code proto.ErrorCode) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s: expected an error", name)
+		return
+	}
+	if e := errors.Parse(err.Error()); e.Status != code.String() || e.Code != httpCodes[code] {
+		t.Errorf("%s: got %v, expected %s", name, err, code)
+	}
+}
+
+func assertCode(t *testing.T, name string, err error, code int32) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s: expected an error", name)
+		return
+	}
+	if e := errors.Parse(err.Error()); e.Code != code {
+		t.Errorf("%s: got %v, expected code %d", name, err, code)
+	}
+}
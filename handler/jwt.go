@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/metadata"
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string  `json:"sub"`
+	ExpiresAt float64 `json:"exp"`
+	NotBefore float64 `json:"nbf"`
+}
+
+// jwtAuthorizer authenticates the callers with the JSON Web Tokens of their
+// Authorization metadata, signed with alg
+type jwtAuthorizer struct {
+	alg    string
+	verify func(signed, signature []byte) bool
+	rules  []Rule
+}
+
+// subject returns the subject of a valid token
+func (a *jwtAuthorizer) subject(token string) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", false
+	}
+	header := &jwtHeader{}
+	// the algorithm is fixed by the key, tokens with another one are rejected
+	if err := json.Unmarshal(b, header); err != nil || header.Alg != a.alg {
+		return "", false
+	}
+	if !a.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return "", false
+	}
+	if b, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+		return "", false
+	}
+	claims := &jwtClaims{}
+	if err := json.Unmarshal(b, claims); err != nil || claims.Subject == "" {
+		return "", false
+	}
+	now := float64(time.Now().Unix())
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt || claims.NotBefore != 0 && now < claims.NotBefore {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+func (a *jwtAuthorizer) Authorize(md metadata.Metadata, op Operation, path string) (string, error) {
+	subject, ok := a.subject(bearerToken(md))
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	if !allowed(a.rules, subject, op, path) {
+		return "", ErrForbidden
+	}
+	return subject, nil
+}
+
+// NewHS256Authorizer authenticates the callers with JSON Web Tokens signed
+// with HMAC-SHA256 and secret, the rules apply to their "sub" claim
+func NewHS256Authorizer(secret []byte, rules ...Rule) Authorizer {
+	return &jwtAuthorizer{
+		alg: "HS256",
+		verify: func(signed, signature []byte) bool {
+			h := hmac.New(sha256.New, secret)
+			h.Write(signed)
+			return hmac.Equal(h.Sum(nil), signature)
+		},
+		rules: rules,
+	}
+}
+
+// NewRS256Authorizer authenticates the callers with JSON Web Tokens signed
+// with RSA-SHA256, which are verified with the public key key, the rules
+// apply to their "sub" claim
+func NewRS256Authorizer(key *rsa.PublicKey, rules ...Rule) Authorizer {
+	return &jwtAuthorizer{
+		alg: "RS256",
+		verify: func(signed, signature []byte) bool {
+			sum := sha256.Sum256(signed)
+			return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signature) == nil
+		},
+		rules: rules,
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+type Option func(o *Options)
+
+// CallerIdentity returns the identity of the caller of a request, sessions
+// can only be used by the caller which opened them
+type CallerIdentity func(ctx context.Context) string
+
+type Options struct {
+	symlinkCheck bool
+	idleTimeout  time.Duration
+	maxSessions  int
+	context      context.Context
+	caller       CallerIdentity
+	uploadDir    string
+	uploadTTL    time.Duration
+	fileMode     os.FileMode
+	authorizer   Authorizer
+}
+
+// WithSymlinkCheck rejects the paths going through a symbolic link pointing
+// outside of the served directory
+func WithSymlinkCheck(check bool) Option {
+	return func(o *Options) {
+		o.symlinkCheck = check
+	}
+}
+
+// WithIdleTimeout closes the sessions which have not been used for d.
+// Reads and writes on a closed session return a session expired error.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.idleTimeout = d
+	}
+}
+
+// WithMaxSessions limits the number of sessions opened at the same time
+func WithMaxSessions(n int) Option {
+	return func(o *Options) {
+		o.maxSessions = n
+	}
+}
+
+// WithContext sets the context stopping the handler background tasks
+func WithContext(ctx context.Context) Option {
+	return func(o *Options) {
+		o.context = ctx
+	}
+}
+
+// WithCallerIdentity sets the function identifying the callers, the
+// CallerMetadataKey metadata value is used by default. This is not
+// authentication: the identity is trusted as is, and the ownership of the
+// sessions is only enforced when it comes from an authentication of the
+// caller. It is not used with an Authorizer, the callers are identified by
+// their authenticated subject and the sessions need a non empty one.
+func WithCallerIdentity(ci CallerIdentity) Option {
+	return func(o *Options) {
+		o.caller = ci
+	}
+}
+
+// WithUploadDir sets the directory of the fs where the upload sessions are
+// kept, it defaults to .uploads in the served directory and is hidden from
+// the clients. It must be on the same device for the commits to be atomic.
+func WithUploadDir(dir string) Option {
+	return func(o *Options) {
+		o.uploadDir = dir
+	}
+}
+
+// WithUploadTTL removes the upload sessions which have not been used for d
+// with their data, they are kept until they are committed or aborted
+// otherwise. The committed uploads are reported as complete for an hour, or
+// d when it is shorter.
+func WithUploadTTL(d time.Duration) Option {
+	return func(o *Options) {
+		o.uploadTTL = d
+	}
+}
+
+// WithFileMode sets the mode of the files created by the atomic creates and
+// the uploads without a mode, 0644 by default. The files they replace keep
+// their mode.
+func WithFileMode(mode os.FileMode) Option {
+	return func(o *Options) {
+		o.fileMode = mode & os.ModePerm
+	}
+}
+
+// WithAuthorizer checks every operation on a path against a, the File
+// service accepts any caller otherwise
+func WithAuthorizer(a Authorizer) Option {
+	return func(o *Options) {
+		o.authorizer = a
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// resolve maps a client supplied name to a path inside the served directory,
+// rejecting absolute paths, traversal, the reserved directories and, if
+// enabled, escaping symlinks
+func (h *handler) resolve(name string) (string, error) {
+	if strings.ContainsRune(name, 0) {
+		return "", newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid path %q", name)
+	}
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" || strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) {
+		return "", newError(proto.ErrorCode_PERMISSION_DENIED, "absolute path %s is not allowed", name)
+	}
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", newError(proto.ErrorCode_PERMISSION_DENIED, "path %s is outside of the served directory", name)
+	}
+	path := filepath.Join(h.dir, rel)
+	if h.reserved(path) {
+		return "", newError(proto.ErrorCode_PERMISSION_DENIED, "path %s is reserved", name)
+	}
+	if h.opts.symlinkCheck {
+		if err := h.checkSymlinks(rel); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// checkSymlinks walks every element of rel and makes sure that the symlinks
+// met along the way resolve inside the served directory. Symlinks targets can
+// only be read on the os filesystem, so they are always rejected elsewhere.
+func (h *handler) checkSymlinks(rel string) error {
+	lstater, ok := h.fs.(afero.Lstater)
+	if !ok || rel == "." {
+		return nil
+	}
+	_, isOs := h.fs.(*afero.OsFs)
+	path := h.dir
+	for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+		path = filepath.Join(path, elem)
+		fi, lstat, err := lstater.LstatIfPossible(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return h.fsError(err)
+		}
+		if !lstat || fi.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		if !isOs || !h.inside(path) {
+			return newError(proto.ErrorCode_PERMISSION_DENIED, "path %s is outside of the served directory", filepath.ToSlash(rel))
+		}
+	}
+	return nil
+}
+
+// inside reports whether the symlink at path resolves inside the served
+// directory, dangling links are never considered inside
+func (h *handler) inside(path string) bool {
+	root, err := filepath.EvalSymlinks(h.dir)
+	if err != nil {
+		return false
+	}
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+	return within(root, target)
+}
+
+func within(root, path string) bool {
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
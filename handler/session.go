@@ -1,43 +1,260 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
 	"sync"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"golang.org/x/net/context"
+
+	proto "github.com/partitio/go-file/proto"
 )
 
+// expiredTTL is how long reaped session ids are remembered as expired
+const expiredTTL = time.Hour
+
 type session struct {
 	sync.Mutex
-	files   map[int64]afero.File
-	counter int64
+	files   map[string]*entry
+	expired map[string]time.Time
+	max     int
+	// reserved counts the sessions being opened, see Reserve
+	reserved int
+}
+
+type entry struct {
+	file       afero.File
+	path       string
+	access     Access
+	owner      string
+	lastAccess time.Time
+	// done is called once the file is closed, abort reports whether the
+	// content written must be discarded
+	done func(abort bool) error
+}
+
+// lockedFile serializes the positional reads and writes of a session, clients
+// transfer blocks in parallel and afero in-memory files are not safe for it.
+// It also enforces the access mode the file was opened with.
+type lockedFile struct {
+	afero.File
+	flag int
+	mu   sync.Mutex
+}
+
+func (f *lockedFile) ReadAt(b []byte, off int64) (int, error) {
+	if f.flag&os.O_WRONLY != 0 {
+		return 0, &os.PathError{Op: "read", Path: f.Name(), Err: os.ErrPermission}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.ReadAt(b, off)
+}
+
+// WriteAt extends the file before writing past its end, afero in-memory files
+// lose their content otherwise when the blocks arrive out of order. Files
+// opened in append mode are written at their end whatever the offset.
+func (f *lockedFile) WriteAt(b []byte, off int64) (int, error) {
+	if f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return 0, &os.PathError{Op: "write", Path: f.Name(), Err: os.ErrPermission}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.flag&os.O_APPEND != 0 {
+		return f.File.Write(b)
+	}
+	if fi, err := f.File.Stat(); err == nil && off > fi.Size() {
+		if err := f.File.Truncate(off); err != nil {
+			return 0, err
+		}
+	}
+	return f.File.WriteAt(b, off)
+}
+
+func (f *lockedFile) Truncate(size int64) error {
+	if f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return &os.PathError{Op: "truncate", Path: f.Name(), Err: os.ErrPermission}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.Truncate(size)
+}
+
+func newSession(max int) *session {
+	return &session{
+		files:   make(map[string]*entry),
+		expired: make(map[string]time.Time),
+		max:     max,
+	}
+}
+
+// newSessionId returns a random 128 bits session id
+func newSessionId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Reserve holds a session for a file about to be opened, so that the limit
+// of sessions is checked before the file is created or truncated. The
+// session is used by Add, or given back by Release if the file cannot be
+// opened.
+func (s *session) Reserve() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.max > 0 && len(s.files)+s.reserved >= s.max {
+		return newError(proto.ErrorCode_QUOTA_EXCEEDED, "too many open sessions (%d)", s.max)
+	}
+	s.reserved++
+	return nil
+}
+
+// Release gives back a session held by Reserve
+func (s *session) Release() {
+	s.Lock()
+	defer s.Unlock()
+	s.reserved--
 }
 
-func (s *session) Add(file afero.File) int64 {
+// Add registers file, opened on path with flag, in a new session owned by
+// owner, in place of a session held by Reserve. done is optionally called
+// when the session is closed.
+func (s *session) Add(file afero.File, path string, flag int, owner string, done func(abort bool) error) (string, error) {
 	s.Lock()
 	defer s.Unlock()
 
-	s.counter += 1
-	s.files[s.counter] = file
+	s.reserved--
+	id, err := newSessionId()
+	if err != nil {
+		closeEntry(&entry{file: file, done: done}, true)
+		return "", newError(proto.ErrorCode_INTERNAL, "cannot create session: %v", err)
+	}
+	access := ReadAccess
+	if flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		access = WriteAccess
+	}
+	s.files[id] = &entry{file: &lockedFile{File: file, flag: flag}, path: path, access: access, owner: owner, lastAccess: time.Now(), done: done}
 
-	return s.counter
+	return id, nil
 }
 
-func (s *session) Get(id int64) afero.File {
+// Path returns the path and the access of the session id, whoever owns it
+func (s *session) Path(id string) (string, Access, error) {
 	s.Lock()
 	defer s.Unlock()
-	return s.files[id]
+	e, ok := s.files[id]
+	if !ok {
+		_, err := s.get(id, "")
+		return "", 0, err
+	}
+	return e.path, e.access, nil
 }
 
-func (s *session) Delete(id int64) {
+// Get returns the file of the session id if it is owned by owner
+func (s *session) Get(id, owner string) (afero.File, error) {
 	s.Lock()
 	defer s.Unlock()
+	e, err := s.get(id, owner)
+	if err != nil {
+		return nil, err
+	}
+	e.lastAccess = time.Now()
+	return e.file, nil
+}
 
-	if file, exist := s.files[id]; exist {
-		file.Close()
-		delete(s.files, id)
+// Delete closes the session id if it is owned by owner, abort discards the
+// content written to atomic sessions
+func (s *session) Delete(id, owner string, abort bool) error {
+	s.Lock()
+	defer s.Unlock()
+
+	e, err := s.get(id, owner)
+	if err != nil {
+		return err
+	}
+	delete(s.files, id)
+	return closeEntry(e, abort)
+}
+
+// closeEntry closes the file of e and completes it, the content is discarded
+// if the file cannot be closed
+func closeEntry(e *entry, abort bool) error {
+	err := e.file.Close()
+	if e.done == nil {
+		return err
+	}
+	if derr := e.done(abort || err != nil); err == nil {
+		err = derr
+	}
+	return err
+}
+
+func (s *session) get(id, owner string) (*entry, error) {
+	e, ok := s.files[id]
+	if !ok {
+		if _, ok := s.expired[id]; ok {
+			return nil, newError(proto.ErrorCode_SESSION_EXPIRED, "session expired")
+		}
+		return nil, newError(proto.ErrorCode_SESSION_NOT_FOUND, "You must call open first.")
+	}
+	if e.owner != owner {
+		return nil, newError(proto.ErrorCode_PERMISSION_DENIED, "session belongs to another caller")
 	}
+	return e, nil
 }
 
 func (s *session) Len() int {
+	s.Lock()
+	defer s.Unlock()
 	return len(s.files)
 }
+
+// Reap closes the sessions which have not been accessed since before now-timeout
+func (s *session) Reap(now time.Time, timeout time.Duration) []string {
+	s.Lock()
+	defer s.Unlock()
+
+	var reaped []string
+	for id, e := range s.files {
+		if now.Sub(e.lastAccess) < timeout {
+			continue
+		}
+		closeEntry(e, true)
+		delete(s.files, id)
+		s.expired[id] = now
+		reaped = append(reaped, id)
+	}
+	for id, t := range s.expired {
+		if now.Sub(t) >= expiredTTL {
+			delete(s.expired, id)
+		}
+	}
+	return reaped
+}
+
+// reaper periodically reaps the idle sessions until ctx is done
+func (s *session) reaper(ctx context.Context, timeout time.Duration) {
+	interval := timeout / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			for _, id := range s.Reap(now, timeout) {
+				logrus.Tracef("Reaped idle sessionId=%s", id)
+			}
+		}
+	}
+}
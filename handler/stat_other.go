@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package handler
+
+import (
+	"os"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// statSys does nothing, the owner and identity of the files are only
+// available on unix systems
+func statSys(fi os.FileInfo, rsp *proto.StatResponse) {}
@@ -0,0 +1,25 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package handler
+
+import (
+	"os"
+	"syscall"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// statSys copies the owner and identity of the file from the system
+// specific information of fi, when it comes from the os filesystem
+func statSys(fi os.FileInfo, rsp *proto.StatResponse) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	rsp.Uid = st.Uid
+	rsp.Gid = st.Gid
+	rsp.Dev = uint64(st.Dev)
+	rsp.Ino = uint64(st.Ino)
+	rsp.Nlink = uint64(st.Nlink)
+}
@@ -0,0 +1,582 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"golang.org/x/net/context"
+
+	proto "github.com/partitio/go-file/proto"
+)
+
+// uploadDir is where the upload sessions are kept, relative to the served
+// directory, unless WithUploadDir is used
+const uploadDir = ".uploads"
+
+// completedTTL is how long a committed upload is reported as complete, so
+// that a client which lost the response of the commit can check it
+const completedTTL = time.Hour
+
+// upload is the persisted state of an upload session
+type upload struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	Mode     uint32 `json:"mode"`
+	Owner    string `json:"owner"`
+	// Ranges are the sorted and merged [start, end) ranges received so far
+	Ranges [][2]int64 `json:"ranges"`
+	// Committed is set with the Checksum of the file once it is published
+	Committed bool   `json:"committed,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+	// Parent and Part are set on the part of a multipart upload, a committed
+	// part is only sealed with its MD5 until its parent is committed
+	Parent string `json:"parent,omitempty"`
+	Part   int32  `json:"part,omitempty"`
+	MD5    string `json:"md5,omitempty"`
+	// Parts are the uploads of the parts of a multipart upload by number
+	Parts map[int32]string `json:"parts,omitempty"`
+}
+
+// add merges the range [start, end) into the received ranges
+func (u *upload) add(start, end int64) {
+	if start == end {
+		return
+	}
+	ranges := append(u.Ranges, [2]int64{start, end})
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r[0] <= last[1] {
+			if r[1] > last[1] {
+				last[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	u.Ranges = merged
+}
+
+func (u *upload) complete() bool {
+	if u.Size == 0 {
+		return true
+	}
+	return len(u.Ranges) == 1 && u.Ranges[0] == [2]int64{0, u.Size}
+}
+
+// uploadLocks serializes the operations on the same upload session
+type uploadLocks [32]sync.Mutex
+
+func (l *uploadLocks) get(id string) *sync.Mutex {
+	return &l[crc32.ChecksumIEEE([]byte(id))%uint32(len(l))]
+}
+
+func (h *handler) uploadPath(id string) string {
+	return filepath.Join(h.opts.uploadDir, id)
+}
+
+func (h *handler) uploadStatePath(id string) string {
+	return filepath.Join(h.opts.uploadDir, id+".json")
+}
+
+// reserved reports whether path is used by the handler for its own data
+func (h *handler) reserved(path string) bool {
+	return within(h.opts.uploadDir, path)
+}
+
+// readUpload returns the state of the upload id
+func (h *handler) readUpload(id string) (*upload, error) {
+	if b, err := hex.DecodeString(id); err != nil || len(b) != 16 {
+		return nil, newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid upload id %q", id)
+	}
+	b, err := afero.ReadFile(h.fs, h.uploadStatePath(id))
+	if os.IsNotExist(err) {
+		return nil, newError(proto.ErrorCode_SESSION_NOT_FOUND, "upload %s not found", id)
+	}
+	if err != nil {
+		return nil, h.fsError(err)
+	}
+	u := &upload{}
+	if err := json.Unmarshal(b, u); err != nil {
+		return nil, newError(proto.ErrorCode_INTERNAL, "invalid upload state: %v", err)
+	}
+	return u, nil
+}
+
+// loadUpload returns the upload id and the path of its file, once the
+// caller is authorized again for method with access on the file and is
+// checked to own the upload
+func (h *handler) loadUpload(ctx context.Context, method string, access Access, id string) (*upload, string, error) {
+	u, err := h.readUpload(id)
+	if err != nil {
+		return nil, "", err
+	}
+	path, owner, err := h.authorizeOwner(ctx, method, access, u.Filename)
+	if err != nil {
+		return nil, "", err
+	}
+	if u.Owner != owner {
+		return nil, "", newError(proto.ErrorCode_PERMISSION_DENIED, "upload belongs to another caller")
+	}
+	return u, path, nil
+}
+
+// saveUpload replaces the state of an upload through a temporary file, so
+// that an interrupted write does not lose the upload
+func (h *handler) saveUpload(id string, u *upload) error {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return newError(proto.ErrorCode_INTERNAL, "%v", err)
+	}
+	file, err := afero.TempFile(h.fs, h.opts.uploadDir, "."+id+".")
+	if err != nil {
+		return h.fsError(err)
+	}
+	_, err = file.Write(b)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = h.fs.Rename(file.Name(), h.uploadStatePath(id))
+	}
+	if err != nil {
+		h.fs.Remove(file.Name())
+		return h.fsError(err)
+	}
+	return nil
+}
+
+// newUpload creates the data file and the state of a new upload
+func (h *handler) newUpload(u *upload) (string, error) {
+	if err := h.fs.MkdirAll(h.opts.uploadDir, 0700); err != nil {
+		return "", h.fsError(err)
+	}
+	id, err := newSessionId()
+	if err != nil {
+		return "", newError(proto.ErrorCode_INTERNAL, "cannot create upload: %v", err)
+	}
+	file, err := h.fs.OpenFile(h.uploadPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", h.fsError(err)
+	}
+	// sized upfront as the chunks can arrive in any order
+	err = file.Truncate(u.Size)
+	file.Close()
+	if err != nil {
+		h.fs.Remove(h.uploadPath(id))
+		return "", h.fsError(err)
+	}
+	if err := h.saveUpload(id, u); err != nil {
+		h.fs.Remove(h.uploadPath(id))
+		return "", err
+	}
+	return id, nil
+}
+
+// removeUpload removes the data and the state of the upload id
+func (h *handler) removeUpload(id string) error {
+	if err := h.fs.Remove(h.uploadPath(id)); err != nil && !os.IsNotExist(err) {
+		return h.fsError(err)
+	}
+	if err := h.fs.Remove(h.uploadStatePath(id)); err != nil {
+		return h.fsError(err)
+	}
+	return nil
+}
+
+// uploadExpiry returns how long an upload is kept once unused, 0 when it is
+// kept until it is committed or aborted
+func (h *handler) uploadExpiry(committed bool) time.Duration {
+	if !committed {
+		return h.opts.uploadTTL
+	}
+	if h.opts.uploadTTL > 0 && h.opts.uploadTTL < completedTTL {
+		return h.opts.uploadTTL
+	}
+	return completedTTL
+}
+
+// reapUploads removes the abandoned uploads, the expired committed uploads
+// and the temporary files left in the upload directory
+func (h *handler) reapUploads(now time.Time) []string {
+	infos, err := afero.ReadDir(h.fs, h.opts.uploadDir)
+	if err != nil {
+		return nil
+	}
+	var reaped []string
+	for _, fi := range infos {
+		if now.Sub(fi.ModTime()) < h.uploadExpiry(true) {
+			continue
+		}
+		if strings.HasPrefix(fi.Name(), ".") {
+			if ttl := h.uploadExpiry(false); ttl > 0 && now.Sub(fi.ModTime()) >= ttl {
+				h.fs.Remove(filepath.Join(h.opts.uploadDir, fi.Name()))
+			}
+			continue
+		}
+		id := strings.TrimSuffix(fi.Name(), ".json")
+		if b, err := hex.DecodeString(id); err != nil || len(b) != 16 {
+			continue
+		}
+		if h.reapUpload(id, now) {
+			reaped = append(reaped, id)
+		}
+	}
+	return reaped
+}
+
+// reapUpload removes the upload id if it is still expired once locked
+func (h *handler) reapUpload(id string, now time.Time) bool {
+	l := h.uploads.get(id)
+	l.Lock()
+	defer l.Unlock()
+
+	committed := false
+	// the data file of an upload without a state is checked instead
+	fi, err := h.fs.Stat(h.uploadStatePath(id))
+	if os.IsNotExist(err) {
+		fi, err = h.fs.Stat(h.uploadPath(id))
+	} else if u, rerr := h.readUpload(id); rerr == nil {
+		// a sealed part is kept as long as an uncommitted upload
+		committed = u.Committed && u.Parent == ""
+	}
+	ttl := h.uploadExpiry(committed)
+	if err != nil || ttl == 0 || now.Sub(fi.ModTime()) < ttl {
+		return false
+	}
+	h.removeUpload(id)
+	return true
+}
+
+// uploadReaper periodically reaps the uploads until ctx is done
+func (h *handler) uploadReaper(ctx context.Context) {
+	interval := h.uploadExpiry(true) / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			for _, id := range h.reapUploads(now) {
+				logrus.Tracef("Reaped uploadId=%s", id)
+			}
+		}
+	}
+}
+
+// UploadBegin starts an upload session of size bytes, the data is only
+// published to filename by UploadCommit. With a parent it starts the upload
+// of a part of the multipart upload parent instead.
+func (h *handler) UploadBegin(ctx context.Context, req *proto.UploadBeginRequest, rsp *proto.UploadBeginResponse) error {
+	if req.Size < 0 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid size %d", req.Size)
+	}
+	if req.Parent != "" {
+		return h.uploadBeginPart(ctx, req, rsp)
+	}
+	path, owner, err := h.authorizeOwner(ctx, "UploadBegin", WriteAccess, req.Filename)
+	if err != nil {
+		return err
+	}
+	if h.isRoot(path) {
+		return newError(proto.ErrorCode_PERMISSION_DENIED, "cannot upload to the served directory")
+	}
+	id, err := h.newUpload(&upload{Filename: req.Filename, Size: req.Size, Mode: req.Mode, Owner: owner})
+	if err != nil {
+		return err
+	}
+	rsp.Id = id
+
+	logrus.Tracef("UploadBegin %s, uploadId=%s, size=%d", req.Filename, id, req.Size)
+
+	return nil
+}
+
+// uploadBeginPart starts the upload of a part of a multipart upload, which
+// replaces the previous upload of the part
+func (h *handler) uploadBeginPart(ctx context.Context, req *proto.UploadBeginRequest, rsp *proto.UploadBeginResponse) error {
+	if req.Part < 1 {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "invalid part %d", req.Part)
+	}
+	l := h.uploads.get(req.Parent)
+	l.Lock()
+	defer l.Unlock()
+
+	parent, _, err := h.loadUpload(ctx, "UploadBegin", WriteAccess, req.Parent)
+	if err != nil {
+		return err
+	}
+	if parent.Committed || parent.Parent != "" {
+		return newError(proto.ErrorCode_SESSION_NOT_FOUND, "upload %s cannot have parts", req.Parent)
+	}
+	id, err := h.newUpload(&upload{Filename: parent.Filename, Size: req.Size, Owner: parent.Owner, Parent: req.Parent, Part: req.Part})
+	if err != nil {
+		return err
+	}
+	if parent.Parts == nil {
+		parent.Parts = make(map[int32]string)
+	}
+	previous := parent.Parts[req.Part]
+	parent.Parts[req.Part] = id
+	if err := h.saveUpload(req.Parent, parent); err != nil {
+		h.removeUpload(id)
+		return err
+	}
+	if previous != "" {
+		h.removeUpload(previous)
+	}
+	rsp.Id = id
+
+	logrus.Tracef("UploadBegin uploadId=%s, part=%d of %s, size=%d", id, req.Part, req.Parent, req.Size)
+
+	return nil
+}
+
+func (h *handler) UploadAppend(ctx context.Context, req *proto.UploadAppendRequest, rsp *proto.UploadAppendResponse) error {
+	l := h.uploads.get(req.Id)
+	l.Lock()
+	defer l.Unlock()
+
+	u, _, err := h.loadUpload(ctx, "UploadAppend", WriteAccess, req.Id)
+	if err != nil {
+		return err
+	}
+	if u.Committed {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "upload %s is committed", req.Id)
+	}
+	end := req.Offset + int64(len(req.Data))
+	if req.Offset < 0 || end > u.Size {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "range %d-%d is outside of the upload", req.Offset, end)
+	}
+	if len(req.Checksum) > 0 && !bytes.Equal(req.Checksum, blockChecksum(req.Data)) {
+		return newError(proto.ErrorCode_CHECKSUM_MISMATCH, "checksum mismatch")
+	}
+	file, err := h.fs.OpenFile(h.uploadPath(req.Id), os.O_WRONLY, 0600)
+	if err != nil {
+		return h.fsError(err)
+	}
+	n, err := file.WriteAt(req.Data, req.Offset)
+	file.Close()
+	if err != nil {
+		return h.fsError(err)
+	}
+	u.add(req.Offset, req.Offset+int64(n))
+	if err := h.saveUpload(req.Id, u); err != nil {
+		return err
+	}
+	rsp.Size = int64(n)
+
+	logrus.Tracef("UploadAppend uploadId=%s, Offset=%d, n=%d", req.Id, req.Offset, n)
+
+	return nil
+}
+
+// UploadStatus returns the ranges received by an upload, a committed upload
+// is reported as complete until it expires
+func (h *handler) UploadStatus(ctx context.Context, req *proto.UploadStatusRequest, rsp *proto.UploadStatusResponse) error {
+	l := h.uploads.get(req.Id)
+	l.Lock()
+	defer l.Unlock()
+
+	u, _, err := h.loadUpload(ctx, "UploadStatus", ReadAccess, req.Id)
+	if err != nil {
+		return err
+	}
+	rsp.Filename = u.Filename
+	rsp.Size = u.Size
+	for _, r := range u.Ranges {
+		rsp.Ranges = append(rsp.Ranges, &proto.Range{Offset: r[0], Length: r[1] - r[0]})
+	}
+	return nil
+}
+
+// UploadCommit publishes a complete upload to its file, after checking the
+// optional SHA-256 of the request. The parts of a multipart upload are only
+// sealed, their parent is published as the concatenation of the given parts.
+func (h *handler) UploadCommit(ctx context.Context, req *proto.UploadCommitRequest, rsp *proto.UploadCommitResponse) error {
+	l := h.uploads.get(req.Id)
+	l.Lock()
+	defer l.Unlock()
+
+	u, path, err := h.loadUpload(ctx, "UploadCommit", WriteAccess, req.Id)
+	if err != nil {
+		return err
+	}
+	// a retried commit returns the outcome of the first one
+	if u.Committed {
+		if req.Checksum != "" && req.Checksum != u.Checksum {
+			return newError(proto.ErrorCode_CHECKSUM_MISMATCH, "checksum mismatch")
+		}
+		rsp.Size = u.Size
+		rsp.Checksum = u.Checksum
+		rsp.Md5 = u.MD5
+		return nil
+	}
+	if !u.complete() {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "upload %s is incomplete", req.Id)
+	}
+	if len(req.Parts) > 0 && u.Parent != "" {
+		return newError(proto.ErrorCode_INVALID_ARGUMENT, "upload %s is a part", req.Id)
+	}
+	if len(req.Parts) > 0 {
+		if err := h.concatParts(req.Id, u, req.Parts); err != nil {
+			return err
+		}
+	}
+
+	file, err := h.fs.Open(h.uploadPath(req.Id))
+	if err != nil {
+		return h.fsError(err)
+	}
+	hash, sum := sha256.New(), md5.New()
+	w := io.Writer(hash)
+	if u.Parent != "" {
+		w = io.MultiWriter(hash, sum)
+	}
+	_, err = io.Copy(w, file)
+	file.Close()
+	if err != nil {
+		return h.fsError(err)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if req.Checksum != "" && req.Checksum != checksum {
+		return newError(proto.ErrorCode_CHECKSUM_MISMATCH, "checksum mismatch")
+	}
+	if u.Parent != "" {
+		u.Committed = true
+		u.Checksum = checksum
+		u.MD5 = hex.EncodeToString(sum.Sum(nil))
+		if err := h.saveUpload(req.Id, u); err != nil {
+			return err
+		}
+		rsp.Size = u.Size
+		rsp.Checksum = u.Checksum
+		rsp.Md5 = u.MD5
+
+		logrus.Tracef("UploadCommit uploadId=%s, part=%d of %s, size=%d", req.Id, u.Part, u.Parent, u.Size)
+
+		return nil
+	}
+
+	mode := os.FileMode(u.Mode) & os.ModePerm
+	if mode == 0 {
+		mode = h.createMode(path)
+	}
+	if err := h.fs.Chmod(h.uploadPath(req.Id), mode); err != nil {
+		return h.fsError(err)
+	}
+	if err := h.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return h.fsError(err)
+	}
+	if err := h.fs.Rename(h.uploadPath(req.Id), path); err != nil {
+		return h.fsError(err)
+	}
+	for _, id := range u.Parts {
+		h.removeUpload(id)
+	}
+	// the state is kept for a while to report the upload as complete
+	u.Committed = true
+	u.Checksum = checksum
+	u.Parts = nil
+	if err := h.saveUpload(req.Id, u); err != nil {
+		logrus.Tracef("UploadCommit uploadId=%s, cannot save the state: %v", req.Id, err)
+		h.fs.Remove(h.uploadStatePath(req.Id))
+	}
+	rsp.Size = u.Size
+	rsp.Checksum = checksum
+
+	logrus.Tracef("UploadCommit %s, uploadId=%s, size=%d", u.Filename, req.Id, u.Size)
+
+	return nil
+}
+
+func (h *handler) UploadAbort(ctx context.Context, req *proto.UploadAbortRequest, rsp *proto.UploadAbortResponse) error {
+	l := h.uploads.get(req.Id)
+	l.Lock()
+	defer l.Unlock()
+
+	u, _, err := h.loadUpload(ctx, "UploadAbort", WriteAccess, req.Id)
+	if err != nil {
+		return err
+	}
+	for _, id := range u.Parts {
+		h.removeUpload(id)
+	}
+	if err := h.removeUpload(req.Id); err != nil {
+		return err
+	}
+
+	logrus.Tracef("UploadAbort uploadId=%s", req.Id)
+
+	return nil
+}
+
+// concatParts replaces the data of the multipart upload id by the
+// concatenation of parts, which must be sealed with the given MD5
+func (h *handler) concatParts(id string, u *upload, parts []*proto.UploadPart) error {
+	file, err := h.fs.OpenFile(h.uploadPath(id), os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return h.fsError(err)
+	}
+	size, err := h.copyParts(file, u, parts)
+	if cerr := file.Close(); err == nil && cerr != nil {
+		err = h.fsError(cerr)
+	}
+	if err != nil {
+		return err
+	}
+	u.Size = size
+	u.Ranges = nil
+	u.add(0, size)
+	return nil
+}
+
+func (h *handler) copyParts(w io.Writer, u *upload, parts []*proto.UploadPart) (int64, error) {
+	var size int64
+	for i, p := range parts {
+		if i > 0 && p.Part <= parts[i-1].Part {
+			return 0, newError(proto.ErrorCode_INVALID_ARGUMENT, "parts are not in ascending order")
+		}
+		id, ok := u.Parts[p.Part]
+		if !ok {
+			return 0, newError(proto.ErrorCode_NOT_FOUND, "part %d not found", p.Part)
+		}
+		// the sealed parts do not change, they are read without their lock
+		part, err := h.readUpload(id)
+		if err != nil || !part.Committed {
+			return 0, newError(proto.ErrorCode_NOT_FOUND, "part %d not found", p.Part)
+		}
+		if !strings.EqualFold(p.Md5, part.MD5) {
+			return 0, newError(proto.ErrorCode_CHECKSUM_MISMATCH, "part %d checksum mismatch", p.Part)
+		}
+		f, err := h.fs.Open(h.uploadPath(id))
+		if err != nil {
+			return 0, h.fsError(err)
+		}
+		n, err := io.Copy(w, f)
+		f.Close()
+		if err != nil {
+			return 0, h.fsError(err)
+		}
+		size += n
+	}
+	return size, nil
+}
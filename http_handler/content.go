@@ -0,0 +1,87 @@
+package http_handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/partitio/go-file/client"
+)
+
+// maxETags is the number of ETags kept by an etagCache
+const maxETags = 1024
+
+// strongETagSize is the size up to which the strong ETag of a file is
+// computed by its first download, the larger files get a weak ETag until a
+// conditional request needs the strong one
+const strongETagSize = client.BlockSize
+
+type etag struct {
+	size    int64
+	modTime time.Time
+	value   string
+}
+
+// etagCache remembers the ETags of the downloaded files, they are computed
+// again only when the size or the modification time of a file changes
+type etagCache struct {
+	mu    sync.Mutex
+	etags map[string]etag
+}
+
+// get returns the ETag of the file name. It is the strong ETag built from
+// the SHA-256 of its content when it is cached, when strong is set or when
+// the file is small, and a weak ETag built from the size and the
+// modification time of the file otherwise, or when the server cannot
+// compute the checksum.
+func (e *etagCache) get(c client.FileClient, name string, fi os.FileInfo, strong bool) string {
+	e.mu.Lock()
+	v, ok := e.etags[name]
+	e.mu.Unlock()
+	if ok && v.size == fi.Size() && v.modTime.Equal(fi.ModTime()) {
+		return v.value
+	}
+	weak := fmt.Sprintf(`W/"%x-%x"`, fi.Size(), fi.ModTime().UnixNano())
+	if !strong && fi.Size() > strongETagSize {
+		return weak
+	}
+	rsp, err := c.Checksum(name, 0)
+	if err != nil || rsp.Size != fi.Size() {
+		return weak
+	}
+	v = etag{size: fi.Size(), modTime: fi.ModTime(), value: fmt.Sprintf("%q", rsp.Checksum)}
+	e.mu.Lock()
+	if e.etags == nil || len(e.etags) >= maxETags {
+		e.etags = make(map[string]etag)
+	}
+	e.etags[name] = v
+	e.mu.Unlock()
+	return v.value
+}
+
+var errTooLarge = errors.New("request body too large")
+
+// maxReader reads at most n bytes from r, and fails with errTooLarge when r
+// is longer than that
+type maxReader struct {
+	r io.Reader
+	n int64
+}
+
+func (m *maxReader) Read(p []byte) (int, error) {
+	if m.n < 0 {
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > m.n+1 {
+		p = p[:m.n+1]
+	}
+	n, err := m.r.Read(p)
+	m.n -= int64(n)
+	if m.n < 0 {
+		return n + int(m.n), errTooLarge
+	}
+	return n, err
+}
@@ -3,12 +3,16 @@ package http_handler
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
-	"path/filepath"
-	"time"
+	"os"
+	"path"
+	"strings"
+	"syscall"
 
+	merrors "github.com/micro/go-micro/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/partitio/go-file/client"
@@ -23,82 +27,135 @@ type Handler interface {
 type fileHandler struct {
 	client client.FileClient
 	opts   *Options
+	etags  etagCache
 }
 
 func (f *fileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if id, ok := f.tusId(r); ok {
+		f.serveTus(w, r, id)
+		return
+	}
 	switch r.Method {
-	case http.MethodGet:
+	case http.MethodGet, http.MethodHead:
 		f.Download(w, r)
-	case http.MethodPost:
+	case http.MethodPost, http.MethodPut:
 		f.Upload(w, r)
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
 }
 
+// name returns the remote file name of the request url path, relative to
+// the served directory once the prefix is stripped
+func (f *fileHandler) name(r *http.Request) (string, bool) {
+	p := r.URL.Path
+	if !strings.HasPrefix(p, f.opts.prefix) {
+		return "", false
+	}
+	p = strings.TrimPrefix(p, f.opts.prefix)
+	// the prefix /a matches /a/b but not /ab
+	if p != "" && !strings.HasPrefix(p, "/") && !strings.HasSuffix(f.opts.prefix, "/") {
+		return "", false
+	}
+	if strings.ContainsRune(p, 0) {
+		return "", false
+	}
+	// cleaning a rooted path removes every ".." element
+	return strings.TrimPrefix(path.Clean("/"+p), "/"), true
+}
+
 func (f *fileHandler) Download(w http.ResponseWriter, r *http.Request) {
-	n := filepath.Base(r.RequestURI)
+	n, ok := f.name(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
 	logrus.Trace("download request: ", n)
 	ctx := f.opts.headersMatcher(r.Header)
-	file, _, err := f.client.WithContext(ctx).Open(n)
+	c := f.client.WithContext(ctx)
+	file, _, err := c.Open(n)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 	defer file.Close()
-	http.ServeContent(w, r, n, time.Now(), file)
+	fi, err := file.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	if fi.IsDir() {
+		http.Error(w, n+" is a directory", http.StatusBadRequest)
+		return
+	}
+	// http.ServeContent handles If-None-Match and If-Range once the ETag is set
+	strong := r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Range") != ""
+	w.Header().Set("Etag", f.etags.get(c, n, fi, strong))
+	http.ServeContent(w, r, path.Base(n), fi.ModTime(), client.NewBlockReader(file, fi.Size()))
 }
 
+// Upload stores the files of a multipart form posted with the "file" key in
+// the directory of the url path, or the body of a PUT request at the url
+// path, streaming them to the File service
 func (f *fileHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	logrus.Trace("Received upload request")
-	// Parse our multipart form, 10 << 20 specifies a maximum
-	// upload of 10 MB files.
-	if err := r.ParseMultipartForm(client.BlockSize); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
 	defer r.Body.Close()
-	// FormFile returns the first file for the given key `myFile`
-	// it also returns the FileHeader so we can get the Filename,
-	// the Header and the size of the file
-	logrus.Trace("Getting file infos")
-	file, handler, err := r.FormFile("file")
-	if err != nil {
-		fmt.Println("Error Retrieving the File")
-		fmt.Println(err)
+	n, ok := f.name(r)
+	if !ok {
+		http.NotFound(w, r)
 		return
 	}
-	defer file.Close()
-	logrus.Tracef("Uploading File: %+v\n", handler.Filename)
-	logrus.Tracef("File Size: %+v\n", handler.Size)
-	logrus.Tracef("MIME Header: %+v\n", handler.Header)
-
-	ctx := f.opts.headersMatcher(r.Header)
-
-	id, err := f.client.WithContext(ctx).Create(handler.Filename)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if f.opts.maxUploadSize > 0 && r.ContentLength > f.opts.maxUploadSize {
+		http.Error(w, errTooLarge.Error(), http.StatusRequestEntityTooLarge)
 		return
 	}
-	defer f.client.WithContext(ctx).Close(id)
+	body := io.Reader(r.Body)
+	if f.opts.maxUploadSize > 0 {
+		body = &maxReader{r: r.Body, n: f.opts.maxUploadSize}
+	}
+	c := f.client.WithContext(f.opts.headersMatcher(r.Header))
 
-	offset := int64(0)
-	percent := 0
-	for {
-		tp := int(offset * 100 / handler.Size)
-		if percent != tp {
-			percent = tp
-			logrus.Tracef("%s (session id: %d) : Upload %d %%", handler.Filename, id, percent)
+	if r.Method == http.MethodPut {
+		if n == "" {
+			http.Error(w, "missing file name", http.StatusBadRequest)
+			return
 		}
-		b := make([]byte, client.BlockSize)
-		n, err := file.ReadAt(b, offset)
-		if err == io.EOF {
-			break
+		if err := f.write(c, n, body); err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+	} else {
+		r.Body = ioutil.NopCloser(body)
+		mr, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		offset += int64(n)
-		if _, err := f.client.WithContext(ctx).WriteAt(id, offset, b); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		uploaded := 0
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, err.Error(), httpStatus(err))
+				return
+			}
+			if part.FormName() != "file" || part.FileName() == "" {
+				continue
+			}
+			logrus.Tracef("MIME Header: %+v", part.Header)
+			// browsers may send the client path of the file
+			name := path.Base(strings.Replace(part.FileName(), `\`, "/", -1))
+			if err := f.write(c, path.Join(n, name), part); err != nil {
+				http.Error(w, err.Error(), httpStatus(err))
+				return
+			}
+			uploaded++
+		}
+		if uploaded == 0 {
+			http.Error(w, "no file in the form", http.StatusBadRequest)
 			return
 		}
 	}
@@ -107,6 +164,74 @@ func (f *fileHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	w.Write(res)
 }
 
+// write streams r to the file name, which is only replaced once all of r is
+// written on servers supporting atomic creation
+func (f *fileHandler) write(c client.FileClient, name string, r io.Reader) error {
+	logrus.Tracef("Uploading File: %s", name)
+	if dir := path.Dir(name); dir != "." {
+		if err := c.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	id, err := c.CreateAtomic(name)
+	if err != nil {
+		return err
+	}
+	b := make([]byte, client.BlockSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(r, b)
+		if n > 0 {
+			if _, err := c.WriteAt(id, offset, b[:n]); err != nil {
+				c.Abort(id)
+				return err
+			}
+			offset += int64(n)
+			logrus.Tracef("%s (session id: %s) : Uploaded %d bytes", name, id, offset)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			c.Abort(id)
+			return err
+		}
+	}
+	return c.Close(id)
+}
+
+// httpStatus returns the http status matching an error of the client
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, errTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case os.IsNotExist(err):
+		return http.StatusNotFound
+	case os.IsExist(err):
+		return http.StatusConflict
+	case errors.Is(err, client.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case os.IsPermission(err):
+		return http.StatusForbidden
+	case errors.Is(err, syscall.EISDIR), errors.Is(err, syscall.ENOTDIR):
+		return http.StatusBadRequest
+	case errors.Is(err, syscall.ENOTEMPTY):
+		return http.StatusConflict
+	case errors.Is(err, client.ErrSessionNotFound), errors.Is(err, client.ErrSessionExpired):
+		return http.StatusGone
+	case errors.Is(err, client.ErrQuotaExceeded):
+		return http.StatusInsufficientStorage
+	case errors.Is(err, client.ErrChecksumMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, client.ErrNotSupported):
+		return http.StatusNotImplemented
+	}
+	if e, ok := err.(*merrors.Error); ok && e.Code >= 400 {
+		return int(e.Code)
+	}
+	return http.StatusInternalServerError
+}
+
 func NewFileHandler(client client.FileClient, options ...Option) http.Handler {
 	o := &Options{}
 	for _, v := range options {
@@ -117,5 +242,5 @@ func NewFileHandler(client client.FileClient, options ...Option) http.Handler {
 			return context.Background()
 		}
 	}
-	return &fileHandler{client, o}
+	return &fileHandler{client: client, opts: o}
 }
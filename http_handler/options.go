@@ -3,6 +3,8 @@ package http_handler
 import (
 	"context"
 	"net/http"
+
+	"github.com/micro/go-micro/metadata"
 )
 
 type Option func(o *Options)
@@ -11,6 +13,9 @@ type HeaderMatcher func(h http.Header) context.Context
 
 type Options struct {
 	headersMatcher HeaderMatcher
+	maxUploadSize  int64
+	prefix         string
+	tusPrefix      string
 }
 
 func WithHeaderMatcher(hm HeaderMatcher) Option {
@@ -18,3 +23,44 @@ func WithHeaderMatcher(hm HeaderMatcher) Option {
 		o.headersMatcher = hm
 	}
 }
+
+// ForwardHeaders returns a HeaderMatcher passing the headers keys of the
+// requests to the File service as metadata, such as the Authorization header
+// checked by its Authorizer
+func ForwardHeaders(keys ...string) HeaderMatcher {
+	return func(h http.Header) context.Context {
+		md := make(metadata.Metadata)
+		for _, k := range keys {
+			if v := h.Get(k); v != "" {
+				md[k] = v
+			}
+		}
+		return metadata.NewContext(context.Background(), md)
+	}
+}
+
+// WithMaxUploadSize limits the size of the uploaded request bodies, larger
+// uploads are rejected with 413 Request Entity Too Large
+func WithMaxUploadSize(size int64) Option {
+	return func(o *Options) {
+		o.maxUploadSize = size
+	}
+}
+
+// WithPrefix strips prefix from the url paths, the rest of the path is the
+// name of the file relative to the served directory. Requests outside of
+// prefix are not found.
+func WithPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.prefix = prefix
+	}
+}
+
+// WithTusPrefix serves the tus resumable upload protocol under prefix, the
+// "filename" metadata of the uploads is the name of the file relative to the
+// served directory
+func WithTusPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.tusPrefix = prefix
+	}
+}
@@ -0,0 +1,175 @@
+package http_handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the query parameters of a signed url
+const (
+	signExpires     = "X-File-Expires"
+	signMaxSize     = "X-File-Max-Size"
+	signContentType = "X-File-Content-Type"
+	signSignature   = "X-File-Signature"
+)
+
+type signParams struct {
+	maxSize     int64
+	contentType string
+}
+
+type SignOption func(p *signParams)
+
+// SignMaxSize limits the size of the body uploaded with the signed url
+func SignMaxSize(size int64) SignOption {
+	return func(p *signParams) {
+		p.maxSize = size
+	}
+}
+
+// SignContentType restricts the media type of the body uploaded with the
+// signed url
+func SignContentType(contentType string) SignOption {
+	return func(p *signParams) {
+		p.contentType = contentType
+	}
+}
+
+// signature returns the HMAC-SHA256 of the request fields covered by a
+// signed url
+func signature(key []byte, method, path, expires, maxSize, contentType string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(strings.Join([]string{method, path, expires, maxSize, contentType}, "\n")))
+	return h.Sum(nil)
+}
+
+// signedMethods are the methods of the signed urls, the uploads are PUT
+// requests so that a url only gives access to the file of its path
+var signedMethods = map[string]bool{
+	http.MethodGet:  true,
+	http.MethodHead: true,
+	http.MethodPut:  true,
+}
+
+// Sign returns rawurl with the query parameters allowing a request with
+// method to its path until expires, without any other credentials, once
+// verified by the handler returned by NewSignedHandler with the same key.
+// The method is GET or HEAD for a download and PUT for an upload.
+func Sign(key []byte, method, rawurl string, expires time.Time, options ...SignOption) (string, error) {
+	if len(key) == 0 {
+		return "", errors.New("empty signing key")
+	}
+	if !signedMethods[method] {
+		return "", fmt.Errorf("method %s cannot be signed", method)
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+	p := &signParams{}
+	for _, v := range options {
+		v(p)
+	}
+	q := u.Query()
+	q.Set(signExpires, strconv.FormatInt(expires.Unix(), 10))
+	if p.maxSize > 0 {
+		q.Set(signMaxSize, strconv.FormatInt(p.maxSize, 10))
+	}
+	if p.contentType != "" {
+		q.Set(signContentType, p.contentType)
+	}
+	sig := signature(key, method, u.Path, q.Get(signExpires), q.Get(signMaxSize), q.Get(signContentType))
+	q.Set(signSignature, base64.RawURLEncoding.EncodeToString(sig))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// signedHandler only serves the requests with a valid signed url
+type signedHandler struct {
+	key  []byte
+	next http.Handler
+}
+
+func (s *signedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// a signed multipart POST would upload any file below its path
+	if !signedMethods[r.Method] {
+		http.Error(w, "method not allowed with a signed url", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	sig, err := base64.RawURLEncoding.DecodeString(q.Get(signSignature))
+	if err != nil || len(sig) == 0 {
+		http.Error(w, "missing or invalid signature", http.StatusForbidden)
+		return
+	}
+	expires, err := strconv.ParseInt(q.Get(signExpires), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid expiry", http.StatusForbidden)
+		return
+	}
+	var maxSize int64
+	if v := q.Get(signMaxSize); v != "" {
+		if maxSize, err = strconv.ParseInt(v, 10, 64); err != nil || maxSize <= 0 {
+			http.Error(w, "invalid maximum size", http.StatusForbidden)
+			return
+		}
+	}
+	contentType := q.Get(signContentType)
+	if !hmac.Equal(sig, signature(s.key, r.Method, r.URL.Path, q.Get(signExpires), q.Get(signMaxSize), contentType)) {
+		http.Error(w, "signature mismatch", http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() > expires {
+		http.Error(w, "url expired", http.StatusForbidden)
+		return
+	}
+
+	// the restrictions apply to the uploaded body
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		s.next.ServeHTTP(w, r)
+		return
+	}
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || !strings.EqualFold(mediaType, contentType) {
+			http.Error(w, "unexpected content type", http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+	if maxSize > 0 {
+		if r.ContentLength > maxSize {
+			http.Error(w, errTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = &maxReadCloser{maxReader: maxReader{r: r.Body, n: maxSize}, c: r.Body}
+	}
+	s.next.ServeHTTP(w, r)
+}
+
+// maxReadCloser is a maxReader closing the request body
+type maxReadCloser struct {
+	maxReader
+	c io.Closer
+}
+
+func (m *maxReadCloser) Close() error {
+	return m.c.Close()
+}
+
+// NewSignedHandler returns a middleware serving with next the requests
+// whose url is signed by Sign with key, other requests are forbidden. The
+// maximum size and the content type of the signature are enforced on the
+// uploads.
+func NewSignedHandler(key []byte, next http.Handler) http.Handler {
+	return &signedHandler{key: key, next: next}
+}
@@ -0,0 +1,298 @@
+package http_handler
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"hash"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/partitio/go-file/client"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,termination,checksum"
+	// tusContentType is the content type of the PATCH requests
+	tusContentType = "application/offset+octet-stream"
+	// statusChecksumMismatch is the tus status of a chunk which does not
+	// match its Upload-Checksum
+	statusChecksumMismatch = 460
+	// maxChecksumChunk is the largest PATCH body with an Upload-Checksum,
+	// these chunks are held in memory until they are verified
+	maxChecksumChunk = 64 * 1024 * 1024
+)
+
+// tusChecksums are the hashes supported in the Upload-Checksum header
+var tusChecksums = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// tusId returns the upload id of a request url path below the tus prefix
+func (f *fileHandler) tusId(r *http.Request) (string, bool) {
+	if f.opts.tusPrefix == "" || !strings.HasPrefix(r.URL.Path, f.opts.tusPrefix) {
+		return "", false
+	}
+	p := strings.TrimPrefix(r.URL.Path, f.opts.tusPrefix)
+	if p != "" && !strings.HasPrefix(p, "/") && !strings.HasSuffix(f.opts.tusPrefix, "/") {
+		return "", false
+	}
+	return strings.Trim(p, "/"), true
+}
+
+// serveTus implements the tus 1.0 resumable upload protocol with the
+// creation, termination and checksum extensions. The uploads are the upload
+// sessions of the File service, so they survive a restart of the handler.
+func (f *fileHandler) serveTus(w http.ResponseWriter, r *http.Request, id string) {
+	logrus.Tracef("tus request: %s %s", r.Method, r.URL.Path)
+	w.Header().Set("Tus-Resumable", tusVersion)
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Tus-Version", tusVersion)
+		w.Header().Set("Tus-Extension", tusExtensions)
+		w.Header().Set("Tus-Checksum-Algorithm", "md5,sha1,sha256")
+		if f.opts.maxUploadSize > 0 {
+			w.Header().Set("Tus-Max-Size", strconv.FormatInt(f.opts.maxUploadSize, 10))
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Header.Get("Tus-Resumable") != tusVersion {
+		w.Header().Set("Tus-Version", tusVersion)
+		http.Error(w, "unsupported tus version", http.StatusPreconditionFailed)
+		return
+	}
+	c := f.client.WithContext(f.opts.headersMatcher(r.Header))
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		f.tusCreate(c, w, r)
+	case id == "" || strings.Contains(id, "/"):
+		http.NotFound(w, r)
+	case r.Method == http.MethodHead:
+		f.tusHead(c, w, r, id)
+	case r.Method == http.MethodPatch:
+		f.tusPatch(c, w, r, id)
+	case r.Method == http.MethodDelete:
+		if err := c.UploadAbort(id); err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// tusMetadata parses an Upload-Metadata header, a comma separated list of
+// keys followed by their base64 encoded value
+func tusMetadata(header string) (map[string]string, bool) {
+	m := make(map[string]string)
+	for _, v := range strings.Split(header, ",") {
+		kv := strings.Fields(v)
+		if len(kv) == 0 || len(kv) > 2 {
+			if strings.TrimSpace(v) == "" {
+				continue
+			}
+			return nil, false
+		}
+		var value []byte
+		if len(kv) == 2 {
+			var err error
+			if value, err = base64.StdEncoding.DecodeString(kv[1]); err != nil {
+				return nil, false
+			}
+		}
+		m[kv[0]] = string(value)
+	}
+	return m, true
+}
+
+// tusCreate starts an upload session for the file named by the "filename"
+// metadata, relative to the served directory
+func (f *fileHandler) tusCreate(c client.FileClient, w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Upload-Defer-Length") != "" {
+		http.Error(w, "deferred upload length is not supported", http.StatusBadRequest)
+		return
+	}
+	size, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || size < 0 {
+		http.Error(w, "invalid Upload-Length", http.StatusBadRequest)
+		return
+	}
+	if f.opts.maxUploadSize > 0 && size > f.opts.maxUploadSize {
+		http.Error(w, errTooLarge.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	metadata, ok := tusMetadata(r.Header.Get("Upload-Metadata"))
+	if !ok {
+		http.Error(w, "invalid Upload-Metadata", http.StatusBadRequest)
+		return
+	}
+	name := strings.TrimPrefix(path.Clean("/"+strings.Replace(metadata["filename"], `\`, "/", -1)), "/")
+	if name == "" || strings.ContainsRune(name, 0) {
+		http.Error(w, "missing filename metadata", http.StatusBadRequest)
+		return
+	}
+	id, err := c.UploadBegin(name, size, 0)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	logrus.Tracef("tus upload %s created for %s, size=%d", id, name, size)
+	// an empty file is complete once created
+	if size == 0 {
+		if err := c.UploadCommit(id, ""); err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+	}
+	w.Header().Set("Location", path.Join(f.opts.tusPrefix, id))
+	w.Header().Set("Upload-Offset", "0")
+	w.WriteHeader(http.StatusCreated)
+}
+
+// tusOffset returns the name, the size and the offset of an upload, the
+// offset is the end of the data received from its start
+func tusOffset(c client.FileClient, id string) (string, int64, int64, error) {
+	rsp, err := c.UploadStatus(id)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	var offset int64
+	if len(rsp.Ranges) > 0 && rsp.Ranges[0].Offset == 0 {
+		offset = rsp.Ranges[0].Length
+	}
+	return rsp.Filename, rsp.Size, offset, nil
+}
+
+func (f *fileHandler) tusHead(c client.FileClient, w http.ResponseWriter, r *http.Request, id string) {
+	w.Header().Set("Cache-Control", "no-store")
+	name, size, offset, err := tusOffset(c, id)
+	if err != nil {
+		w.WriteHeader(httpStatus(err))
+		return
+	}
+	w.Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Upload-Metadata", "filename "+base64.StdEncoding.EncodeToString([]byte(name)))
+	w.WriteHeader(http.StatusOK)
+}
+
+// tusPatch appends the body at the offset of the upload, and commits the
+// upload once it is complete
+func (f *fileHandler) tusPatch(c client.FileClient, w http.ResponseWriter, r *http.Request, id string) {
+	defer r.Body.Close()
+	if r.Header.Get("Content-Type") != tusContentType {
+		http.Error(w, "invalid Content-Type", http.StatusUnsupportedMediaType)
+		return
+	}
+	start, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || start < 0 {
+		http.Error(w, "invalid Upload-Offset", http.StatusBadRequest)
+		return
+	}
+	_, size, offset, err := tusOffset(c, id)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	if start != offset {
+		w.Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+		http.Error(w, "mismatched Upload-Offset", http.StatusConflict)
+		return
+	}
+	if r.ContentLength > size-offset {
+		http.Error(w, errTooLarge.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	body := io.Reader(&maxReader{r: r.Body, n: size - offset})
+
+	// a chunk with a checksum is only appended once it is verified
+	if v := r.Header.Get("Upload-Checksum"); v != "" {
+		b, err := tusVerify(v, body)
+		if err != nil {
+			http.Error(w, err.Error(), err.status)
+			return
+		}
+		body = bytes.NewReader(b)
+	}
+
+	b := make([]byte, client.BlockSize)
+	for {
+		n, rerr := io.ReadFull(body, b)
+		// the received data is kept when the request is interrupted
+		if n > 0 {
+			if err := c.UploadAppend(id, offset, b[:n]); err != nil {
+				http.Error(w, err.Error(), httpStatus(err))
+				return
+			}
+			offset += int64(n)
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rerr != nil {
+			http.Error(w, rerr.Error(), httpStatus(rerr))
+			return
+		}
+	}
+	logrus.Tracef("tus upload %s: %d of %d bytes received", id, offset, size)
+	if offset == size {
+		if err := c.UploadCommit(id, ""); err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+	}
+	w.Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tusError is an error of a tus request with its http status
+type tusError struct {
+	status int
+	msg    string
+}
+
+func (e *tusError) Error() string {
+	return e.msg
+}
+
+// tusVerify reads r and checks it against an Upload-Checksum header, the
+// algorithm followed by the base64 encoded checksum
+func tusVerify(header string, r io.Reader) ([]byte, *tusError) {
+	v := strings.Fields(header)
+	if len(v) != 2 {
+		return nil, &tusError{http.StatusBadRequest, "invalid Upload-Checksum"}
+	}
+	newHash, ok := tusChecksums[v[0]]
+	if !ok {
+		return nil, &tusError{http.StatusBadRequest, "unsupported checksum algorithm " + v[0]}
+	}
+	expected, err := base64.StdEncoding.DecodeString(v[1])
+	if err != nil {
+		return nil, &tusError{http.StatusBadRequest, "invalid Upload-Checksum"}
+	}
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, maxChecksumChunk+1))
+	if err != nil {
+		return nil, &tusError{httpStatus(err), err.Error()}
+	}
+	if n > maxChecksumChunk {
+		return nil, &tusError{http.StatusRequestEntityTooLarge, "chunk too large for a checksum"}
+	}
+	h := newHash()
+	h.Write(buf.Bytes())
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return nil, &tusError{statusChecksumMismatch, "checksum mismatch"}
+	}
+	return buf.Bytes(), nil
+}
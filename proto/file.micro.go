@@ -22,6 +22,52 @@ It has these top-level messages:
 	ReadResponse
 	GetRequest
 	GetResponse
+	ListRequest
+	ListResponse
+	FileInfo
+	RemoveRequest
+	RemoveResponse
+	RemoveAllRequest
+	RemoveAllResponse
+	RenameRequest
+	RenameResponse
+	MkdirRequest
+	MkdirResponse
+	MkdirAllRequest
+	MkdirAllResponse
+	FeaturesRequest
+	FeaturesResponse
+	ReadStreamRequest
+	ReadStreamResponse
+	WriteStreamHeader
+	WriteStreamRequest
+	WriteStreamResponse
+	ChecksumRequest
+	ChecksumResponse
+	UploadBeginRequest
+	UploadBeginResponse
+	UploadAppendRequest
+	UploadAppendResponse
+	Range
+	UploadStatusRequest
+	UploadStatusResponse
+	UploadPart
+	UploadCommitRequest
+	UploadCommitResponse
+	UploadAbortRequest
+	UploadAbortResponse
+	OpenFileRequest
+	OpenFileResponse
+	TruncateRequest
+	TruncateResponse
+	SyncRequest
+	SyncResponse
+	ChmodRequest
+	ChmodResponse
+	ChownRequest
+	ChownResponse
+	ChtimesRequest
+	ChtimesResponse
 */
 package file
 
@@ -54,12 +100,33 @@ var _ server.Option
 // Client API for File service
 
 type FileService interface {
+	Features(ctx context.Context, in *FeaturesRequest, opts ...client.CallOption) (*FeaturesResponse, error)
 	Open(ctx context.Context, in *OpenRequest, opts ...client.CallOption) (*OpenResponse, error)
+	OpenFile(ctx context.Context, in *OpenFileRequest, opts ...client.CallOption) (*OpenFileResponse, error)
 	Stat(ctx context.Context, in *StatRequest, opts ...client.CallOption) (*StatResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error)
+	Checksum(ctx context.Context, in *ChecksumRequest, opts ...client.CallOption) (*ChecksumResponse, error)
 	Read(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (*ReadResponse, error)
+	ReadStream(ctx context.Context, in *ReadStreamRequest, opts ...client.CallOption) (File_ReadStreamService, error)
 	Close(ctx context.Context, in *CloseRequest, opts ...client.CallOption) (*CloseResponse, error)
 	Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*CreateResponse, error)
 	Write(ctx context.Context, in *WriteRequest, opts ...client.CallOption) (*WriteResponse, error)
+	WriteStream(ctx context.Context, opts ...client.CallOption) (File_WriteStreamService, error)
+	UploadBegin(ctx context.Context, in *UploadBeginRequest, opts ...client.CallOption) (*UploadBeginResponse, error)
+	UploadAppend(ctx context.Context, in *UploadAppendRequest, opts ...client.CallOption) (*UploadAppendResponse, error)
+	UploadStatus(ctx context.Context, in *UploadStatusRequest, opts ...client.CallOption) (*UploadStatusResponse, error)
+	UploadCommit(ctx context.Context, in *UploadCommitRequest, opts ...client.CallOption) (*UploadCommitResponse, error)
+	UploadAbort(ctx context.Context, in *UploadAbortRequest, opts ...client.CallOption) (*UploadAbortResponse, error)
+	Remove(ctx context.Context, in *RemoveRequest, opts ...client.CallOption) (*RemoveResponse, error)
+	RemoveAll(ctx context.Context, in *RemoveAllRequest, opts ...client.CallOption) (*RemoveAllResponse, error)
+	Rename(ctx context.Context, in *RenameRequest, opts ...client.CallOption) (*RenameResponse, error)
+	Mkdir(ctx context.Context, in *MkdirRequest, opts ...client.CallOption) (*MkdirResponse, error)
+	MkdirAll(ctx context.Context, in *MkdirAllRequest, opts ...client.CallOption) (*MkdirAllResponse, error)
+	Truncate(ctx context.Context, in *TruncateRequest, opts ...client.CallOption) (*TruncateResponse, error)
+	Sync(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*SyncResponse, error)
+	Chmod(ctx context.Context, in *ChmodRequest, opts ...client.CallOption) (*ChmodResponse, error)
+	Chown(ctx context.Context, in *ChownRequest, opts ...client.CallOption) (*ChownResponse, error)
+	Chtimes(ctx context.Context, in *ChtimesRequest, opts ...client.CallOption) (*ChtimesResponse, error)
 }
 
 type fileService struct {
@@ -80,6 +147,16 @@ func NewFileService(name string, c client.Client) FileService {
 	}
 }
 
+func (c *fileService) Features(ctx context.Context, in *FeaturesRequest, opts ...client.CallOption) (*FeaturesResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Features", in)
+	out := new(FeaturesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileService) Open(ctx context.Context, in *OpenRequest, opts ...client.CallOption) (*OpenResponse, error) {
 	req := c.c.NewRequest(c.name, "File.Open", in)
 	out := new(OpenResponse)
@@ -90,6 +167,16 @@ func (c *fileService) Open(ctx context.Context, in *OpenRequest, opts ...client.
 	return out, nil
 }
 
+func (c *fileService) OpenFile(ctx context.Context, in *OpenFileRequest, opts ...client.CallOption) (*OpenFileResponse, error) {
+	req := c.c.NewRequest(c.name, "File.OpenFile", in)
+	out := new(OpenFileResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileService) Stat(ctx context.Context, in *StatRequest, opts ...client.CallOption) (*StatResponse, error) {
 	req := c.c.NewRequest(c.name, "File.Stat", in)
 	out := new(StatResponse)
@@ -100,6 +187,26 @@ func (c *fileService) Stat(ctx context.Context, in *StatRequest, opts ...client.
 	return out, nil
 }
 
+func (c *fileService) List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error) {
+	req := c.c.NewRequest(c.name, "File.List", in)
+	out := new(ListResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Checksum(ctx context.Context, in *ChecksumRequest, opts ...client.CallOption) (*ChecksumResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Checksum", in)
+	out := new(ChecksumResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileService) Read(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (*ReadResponse, error) {
 	req := c.c.NewRequest(c.name, "File.Read", in)
 	out := new(ReadResponse)
@@ -110,6 +217,50 @@ func (c *fileService) Read(ctx context.Context, in *ReadRequest, opts ...client.
 	return out, nil
 }
 
+func (c *fileService) ReadStream(ctx context.Context, in *ReadStreamRequest, opts ...client.CallOption) (File_ReadStreamService, error) {
+	req := c.c.NewRequest(c.name, "File.ReadStream", &ReadStreamRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &fileServiceReadStream{stream}, nil
+}
+
+type File_ReadStreamService interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*ReadStreamResponse, error)
+}
+
+type fileServiceReadStream struct {
+	stream client.Stream
+}
+
+func (x *fileServiceReadStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *fileServiceReadStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *fileServiceReadStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *fileServiceReadStream) Recv() (*ReadStreamResponse, error) {
+	m := new(ReadStreamResponse)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *fileService) Close(ctx context.Context, in *CloseRequest, opts ...client.CallOption) (*CloseResponse, error) {
 	req := c.c.NewRequest(c.name, "File.Close", in)
 	out := new(CloseResponse)
@@ -140,25 +291,253 @@ func (c *fileService) Write(ctx context.Context, in *WriteRequest, opts ...clien
 	return out, nil
 }
 
+func (c *fileService) WriteStream(ctx context.Context, opts ...client.CallOption) (File_WriteStreamService, error) {
+	req := c.c.NewRequest(c.name, "File.WriteStream", &WriteStreamRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &fileServiceWriteStream{stream}, nil
+}
+
+type File_WriteStreamService interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*WriteStreamRequest) error
+}
+
+type fileServiceWriteStream struct {
+	stream client.Stream
+}
+
+func (x *fileServiceWriteStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *fileServiceWriteStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *fileServiceWriteStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *fileServiceWriteStream) Send(m *WriteStreamRequest) error {
+	return x.stream.Send(m)
+}
+
+func (c *fileService) UploadBegin(ctx context.Context, in *UploadBeginRequest, opts ...client.CallOption) (*UploadBeginResponse, error) {
+	req := c.c.NewRequest(c.name, "File.UploadBegin", in)
+	out := new(UploadBeginResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) UploadAppend(ctx context.Context, in *UploadAppendRequest, opts ...client.CallOption) (*UploadAppendResponse, error) {
+	req := c.c.NewRequest(c.name, "File.UploadAppend", in)
+	out := new(UploadAppendResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) UploadStatus(ctx context.Context, in *UploadStatusRequest, opts ...client.CallOption) (*UploadStatusResponse, error) {
+	req := c.c.NewRequest(c.name, "File.UploadStatus", in)
+	out := new(UploadStatusResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) UploadCommit(ctx context.Context, in *UploadCommitRequest, opts ...client.CallOption) (*UploadCommitResponse, error) {
+	req := c.c.NewRequest(c.name, "File.UploadCommit", in)
+	out := new(UploadCommitResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) UploadAbort(ctx context.Context, in *UploadAbortRequest, opts ...client.CallOption) (*UploadAbortResponse, error) {
+	req := c.c.NewRequest(c.name, "File.UploadAbort", in)
+	out := new(UploadAbortResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Remove(ctx context.Context, in *RemoveRequest, opts ...client.CallOption) (*RemoveResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Remove", in)
+	out := new(RemoveResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) RemoveAll(ctx context.Context, in *RemoveAllRequest, opts ...client.CallOption) (*RemoveAllResponse, error) {
+	req := c.c.NewRequest(c.name, "File.RemoveAll", in)
+	out := new(RemoveAllResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Rename(ctx context.Context, in *RenameRequest, opts ...client.CallOption) (*RenameResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Rename", in)
+	out := new(RenameResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Mkdir(ctx context.Context, in *MkdirRequest, opts ...client.CallOption) (*MkdirResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Mkdir", in)
+	out := new(MkdirResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) MkdirAll(ctx context.Context, in *MkdirAllRequest, opts ...client.CallOption) (*MkdirAllResponse, error) {
+	req := c.c.NewRequest(c.name, "File.MkdirAll", in)
+	out := new(MkdirAllResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Truncate(ctx context.Context, in *TruncateRequest, opts ...client.CallOption) (*TruncateResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Truncate", in)
+	out := new(TruncateResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Sync(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*SyncResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Sync", in)
+	out := new(SyncResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Chmod(ctx context.Context, in *ChmodRequest, opts ...client.CallOption) (*ChmodResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Chmod", in)
+	out := new(ChmodResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Chown(ctx context.Context, in *ChownRequest, opts ...client.CallOption) (*ChownResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Chown", in)
+	out := new(ChownResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileService) Chtimes(ctx context.Context, in *ChtimesRequest, opts ...client.CallOption) (*ChtimesResponse, error) {
+	req := c.c.NewRequest(c.name, "File.Chtimes", in)
+	out := new(ChtimesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for File service
 
 type FileHandler interface {
+	Features(context.Context, *FeaturesRequest, *FeaturesResponse) error
 	Open(context.Context, *OpenRequest, *OpenResponse) error
+	OpenFile(context.Context, *OpenFileRequest, *OpenFileResponse) error
 	Stat(context.Context, *StatRequest, *StatResponse) error
+	List(context.Context, *ListRequest, *ListResponse) error
+	Checksum(context.Context, *ChecksumRequest, *ChecksumResponse) error
 	Read(context.Context, *ReadRequest, *ReadResponse) error
+	ReadStream(context.Context, *ReadStreamRequest, File_ReadStreamStream) error
 	Close(context.Context, *CloseRequest, *CloseResponse) error
 	Create(context.Context, *CreateRequest, *CreateResponse) error
 	Write(context.Context, *WriteRequest, *WriteResponse) error
+	WriteStream(context.Context, File_WriteStreamStream) error
+	UploadBegin(context.Context, *UploadBeginRequest, *UploadBeginResponse) error
+	UploadAppend(context.Context, *UploadAppendRequest, *UploadAppendResponse) error
+	UploadStatus(context.Context, *UploadStatusRequest, *UploadStatusResponse) error
+	UploadCommit(context.Context, *UploadCommitRequest, *UploadCommitResponse) error
+	UploadAbort(context.Context, *UploadAbortRequest, *UploadAbortResponse) error
+	Remove(context.Context, *RemoveRequest, *RemoveResponse) error
+	RemoveAll(context.Context, *RemoveAllRequest, *RemoveAllResponse) error
+	Rename(context.Context, *RenameRequest, *RenameResponse) error
+	Mkdir(context.Context, *MkdirRequest, *MkdirResponse) error
+	MkdirAll(context.Context, *MkdirAllRequest, *MkdirAllResponse) error
+	Truncate(context.Context, *TruncateRequest, *TruncateResponse) error
+	Sync(context.Context, *SyncRequest, *SyncResponse) error
+	Chmod(context.Context, *ChmodRequest, *ChmodResponse) error
+	Chown(context.Context, *ChownRequest, *ChownResponse) error
+	Chtimes(context.Context, *ChtimesRequest, *ChtimesResponse) error
 }
 
 func RegisterFileHandler(s server.Server, hdlr FileHandler, opts ...server.HandlerOption) error {
 	type file interface {
+		Features(ctx context.Context, in *FeaturesRequest, out *FeaturesResponse) error
 		Open(ctx context.Context, in *OpenRequest, out *OpenResponse) error
+		OpenFile(ctx context.Context, in *OpenFileRequest, out *OpenFileResponse) error
 		Stat(ctx context.Context, in *StatRequest, out *StatResponse) error
+		List(ctx context.Context, in *ListRequest, out *ListResponse) error
+		Checksum(ctx context.Context, in *ChecksumRequest, out *ChecksumResponse) error
 		Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error
+		ReadStream(ctx context.Context, stream server.Stream) error
 		Close(ctx context.Context, in *CloseRequest, out *CloseResponse) error
 		Create(ctx context.Context, in *CreateRequest, out *CreateResponse) error
 		Write(ctx context.Context, in *WriteRequest, out *WriteResponse) error
+		WriteStream(ctx context.Context, stream server.Stream) error
+		UploadBegin(ctx context.Context, in *UploadBeginRequest, out *UploadBeginResponse) error
+		UploadAppend(ctx context.Context, in *UploadAppendRequest, out *UploadAppendResponse) error
+		UploadStatus(ctx context.Context, in *UploadStatusRequest, out *UploadStatusResponse) error
+		UploadCommit(ctx context.Context, in *UploadCommitRequest, out *UploadCommitResponse) error
+		UploadAbort(ctx context.Context, in *UploadAbortRequest, out *UploadAbortResponse) error
+		Remove(ctx context.Context, in *RemoveRequest, out *RemoveResponse) error
+		RemoveAll(ctx context.Context, in *RemoveAllRequest, out *RemoveAllResponse) error
+		Rename(ctx context.Context, in *RenameRequest, out *RenameResponse) error
+		Mkdir(ctx context.Context, in *MkdirRequest, out *MkdirResponse) error
+		MkdirAll(ctx context.Context, in *MkdirAllRequest, out *MkdirAllResponse) error
+		Truncate(ctx context.Context, in *TruncateRequest, out *TruncateResponse) error
+		Sync(ctx context.Context, in *SyncRequest, out *SyncResponse) error
+		Chmod(ctx context.Context, in *ChmodRequest, out *ChmodResponse) error
+		Chown(ctx context.Context, in *ChownRequest, out *ChownResponse) error
+		Chtimes(ctx context.Context, in *ChtimesRequest, out *ChtimesResponse) error
 	}
 	type File struct {
 		file
@@ -171,18 +550,69 @@ type fileHandler struct {
 	FileHandler
 }
 
+func (h *fileHandler) Features(ctx context.Context, in *FeaturesRequest, out *FeaturesResponse) error {
+	return h.FileHandler.Features(ctx, in, out)
+}
+
 func (h *fileHandler) Open(ctx context.Context, in *OpenRequest, out *OpenResponse) error {
 	return h.FileHandler.Open(ctx, in, out)
 }
 
+func (h *fileHandler) OpenFile(ctx context.Context, in *OpenFileRequest, out *OpenFileResponse) error {
+	return h.FileHandler.OpenFile(ctx, in, out)
+}
+
 func (h *fileHandler) Stat(ctx context.Context, in *StatRequest, out *StatResponse) error {
 	return h.FileHandler.Stat(ctx, in, out)
 }
 
+func (h *fileHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
+	return h.FileHandler.List(ctx, in, out)
+}
+
+func (h *fileHandler) Checksum(ctx context.Context, in *ChecksumRequest, out *ChecksumResponse) error {
+	return h.FileHandler.Checksum(ctx, in, out)
+}
+
 func (h *fileHandler) Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error {
 	return h.FileHandler.Read(ctx, in, out)
 }
 
+func (h *fileHandler) ReadStream(ctx context.Context, stream server.Stream) error {
+	m := new(ReadStreamRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.FileHandler.ReadStream(ctx, m, &fileReadStreamStream{stream})
+}
+
+type File_ReadStreamStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*ReadStreamResponse) error
+}
+
+type fileReadStreamStream struct {
+	stream server.Stream
+}
+
+func (x *fileReadStreamStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *fileReadStreamStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *fileReadStreamStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *fileReadStreamStream) Send(m *ReadStreamResponse) error {
+	return x.stream.Send(m)
+}
+
 func (h *fileHandler) Close(ctx context.Context, in *CloseRequest, out *CloseResponse) error {
 	return h.FileHandler.Close(ctx, in, out)
 }
@@ -194,3 +624,98 @@ func (h *fileHandler) Create(ctx context.Context, in *CreateRequest, out *Create
 func (h *fileHandler) Write(ctx context.Context, in *WriteRequest, out *WriteResponse) error {
 	return h.FileHandler.Write(ctx, in, out)
 }
+
+func (h *fileHandler) WriteStream(ctx context.Context, stream server.Stream) error {
+	return h.FileHandler.WriteStream(ctx, &fileWriteStreamStream{stream})
+}
+
+type File_WriteStreamStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*WriteStreamRequest, error)
+}
+
+type fileWriteStreamStream struct {
+	stream server.Stream
+}
+
+func (x *fileWriteStreamStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *fileWriteStreamStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *fileWriteStreamStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *fileWriteStreamStream) Recv() (*WriteStreamRequest, error) {
+	m := new(WriteStreamRequest)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *fileHandler) UploadBegin(ctx context.Context, in *UploadBeginRequest, out *UploadBeginResponse) error {
+	return h.FileHandler.UploadBegin(ctx, in, out)
+}
+
+func (h *fileHandler) UploadAppend(ctx context.Context, in *UploadAppendRequest, out *UploadAppendResponse) error {
+	return h.FileHandler.UploadAppend(ctx, in, out)
+}
+
+func (h *fileHandler) UploadStatus(ctx context.Context, in *UploadStatusRequest, out *UploadStatusResponse) error {
+	return h.FileHandler.UploadStatus(ctx, in, out)
+}
+
+func (h *fileHandler) UploadCommit(ctx context.Context, in *UploadCommitRequest, out *UploadCommitResponse) error {
+	return h.FileHandler.UploadCommit(ctx, in, out)
+}
+
+func (h *fileHandler) UploadAbort(ctx context.Context, in *UploadAbortRequest, out *UploadAbortResponse) error {
+	return h.FileHandler.UploadAbort(ctx, in, out)
+}
+
+func (h *fileHandler) Remove(ctx context.Context, in *RemoveRequest, out *RemoveResponse) error {
+	return h.FileHandler.Remove(ctx, in, out)
+}
+
+func (h *fileHandler) RemoveAll(ctx context.Context, in *RemoveAllRequest, out *RemoveAllResponse) error {
+	return h.FileHandler.RemoveAll(ctx, in, out)
+}
+
+func (h *fileHandler) Rename(ctx context.Context, in *RenameRequest, out *RenameResponse) error {
+	return h.FileHandler.Rename(ctx, in, out)
+}
+
+func (h *fileHandler) Mkdir(ctx context.Context, in *MkdirRequest, out *MkdirResponse) error {
+	return h.FileHandler.Mkdir(ctx, in, out)
+}
+
+func (h *fileHandler) MkdirAll(ctx context.Context, in *MkdirAllRequest, out *MkdirAllResponse) error {
+	return h.FileHandler.MkdirAll(ctx, in, out)
+}
+
+func (h *fileHandler) Truncate(ctx context.Context, in *TruncateRequest, out *TruncateResponse) error {
+	return h.FileHandler.Truncate(ctx, in, out)
+}
+
+func (h *fileHandler) Sync(ctx context.Context, in *SyncRequest, out *SyncResponse) error {
+	return h.FileHandler.Sync(ctx, in, out)
+}
+
+func (h *fileHandler) Chmod(ctx context.Context, in *ChmodRequest, out *ChmodResponse) error {
+	return h.FileHandler.Chmod(ctx, in, out)
+}
+
+func (h *fileHandler) Chown(ctx context.Context, in *ChownRequest, out *ChownResponse) error {
+	return h.FileHandler.Chown(ctx, in, out)
+}
+
+func (h *fileHandler) Chtimes(ctx context.Context, in *ChtimesRequest, out *ChtimesResponse) error {
+	return h.FileHandler.Chtimes(ctx, in, out)
+}
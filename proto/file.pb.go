@@ -20,6 +20,113 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type OpenFlag int32
+
+const (
+	OpenFlag_O_RDONLY OpenFlag = 0
+	OpenFlag_O_WRONLY OpenFlag = 1
+	OpenFlag_O_RDWR   OpenFlag = 2
+	OpenFlag_O_APPEND OpenFlag = 4
+	OpenFlag_O_CREATE OpenFlag = 8
+	OpenFlag_O_EXCL   OpenFlag = 16
+	OpenFlag_O_SYNC   OpenFlag = 32
+	OpenFlag_O_TRUNC  OpenFlag = 64
+)
+
+var OpenFlag_name = map[int32]string{
+	0:  "O_RDONLY",
+	1:  "O_WRONLY",
+	2:  "O_RDWR",
+	4:  "O_APPEND",
+	8:  "O_CREATE",
+	16: "O_EXCL",
+	32: "O_SYNC",
+	64: "O_TRUNC",
+}
+
+var OpenFlag_value = map[string]int32{
+	"O_RDONLY": 0,
+	"O_WRONLY": 1,
+	"O_RDWR":   2,
+	"O_APPEND": 4,
+	"O_CREATE": 8,
+	"O_EXCL":   16,
+	"O_SYNC":   32,
+	"O_TRUNC":  64,
+}
+
+func (x OpenFlag) String() string {
+	return proto.EnumName(OpenFlag_name, int32(x))
+}
+
+func (OpenFlag) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{0}
+}
+
+type ErrorCode int32
+
+const (
+	ErrorCode_UNKNOWN           ErrorCode = 0
+	ErrorCode_INTERNAL          ErrorCode = 1
+	ErrorCode_INVALID_ARGUMENT  ErrorCode = 2
+	ErrorCode_NOT_FOUND         ErrorCode = 3
+	ErrorCode_EXISTS            ErrorCode = 4
+	ErrorCode_PERMISSION_DENIED ErrorCode = 5
+	ErrorCode_IS_DIRECTORY      ErrorCode = 6
+	ErrorCode_NOT_DIRECTORY     ErrorCode = 7
+	ErrorCode_NOT_EMPTY         ErrorCode = 8
+	ErrorCode_SESSION_NOT_FOUND ErrorCode = 9
+	ErrorCode_SESSION_EXPIRED   ErrorCode = 10
+	ErrorCode_QUOTA_EXCEEDED    ErrorCode = 11
+	ErrorCode_CHECKSUM_MISMATCH ErrorCode = 12
+	ErrorCode_NOT_SUPPORTED     ErrorCode = 13
+	ErrorCode_UNAUTHENTICATED   ErrorCode = 14
+)
+
+var ErrorCode_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "INTERNAL",
+	2:  "INVALID_ARGUMENT",
+	3:  "NOT_FOUND",
+	4:  "EXISTS",
+	5:  "PERMISSION_DENIED",
+	6:  "IS_DIRECTORY",
+	7:  "NOT_DIRECTORY",
+	8:  "NOT_EMPTY",
+	9:  "SESSION_NOT_FOUND",
+	10: "SESSION_EXPIRED",
+	11: "QUOTA_EXCEEDED",
+	12: "CHECKSUM_MISMATCH",
+	13: "NOT_SUPPORTED",
+	14: "UNAUTHENTICATED",
+}
+
+var ErrorCode_value = map[string]int32{
+	"UNKNOWN":           0,
+	"INTERNAL":          1,
+	"INVALID_ARGUMENT":  2,
+	"NOT_FOUND":         3,
+	"EXISTS":            4,
+	"PERMISSION_DENIED": 5,
+	"IS_DIRECTORY":      6,
+	"NOT_DIRECTORY":     7,
+	"NOT_EMPTY":         8,
+	"SESSION_NOT_FOUND": 9,
+	"SESSION_EXPIRED":   10,
+	"QUOTA_EXCEEDED":    11,
+	"CHECKSUM_MISMATCH": 12,
+	"NOT_SUPPORTED":     13,
+	"UNAUTHENTICATED":   14,
+}
+
+func (x ErrorCode) String() string {
+	return proto.EnumName(ErrorCode_name, int32(x))
+}
+
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{1}
+}
+
 type OpenRequest struct {
 	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -60,7 +167,7 @@ func (m *OpenRequest) GetFilename() string {
 }
 
 type OpenResponse struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	Result               bool     `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -92,11 +199,11 @@ func (m *OpenResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_OpenResponse proto.InternalMessageInfo
 
-func (m *OpenResponse) GetId() int64 {
+func (m *OpenResponse) GetId() string {
 	if m != nil {
 		return m.Id
 	}
-	return 0
+	return ""
 }
 
 func (m *OpenResponse) GetResult() bool {
@@ -107,7 +214,8 @@ func (m *OpenResponse) GetResult() bool {
 }
 
 type CloseRequest struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Abort                bool     `protobuf:"varint,2,opt,name=abort,proto3" json:"abort,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -138,11 +246,18 @@ func (m *CloseRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CloseRequest proto.InternalMessageInfo
 
-func (m *CloseRequest) GetId() int64 {
+func (m *CloseRequest) GetId() string {
 	if m != nil {
 		return m.Id
 	}
-	return 0
+	return ""
+}
+
+func (m *CloseRequest) GetAbort() bool {
+	if m != nil {
+		return m.Abort
+	}
+	return false
 }
 
 type CloseResponse struct {
@@ -219,6 +334,14 @@ type StatResponse struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	LastModified         int64    `protobuf:"varint,3,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	Mode                 uint32   `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	ModTime              int64    `protobuf:"varint,5,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	LinkTarget           string   `protobuf:"bytes,6,opt,name=link_target,json=linkTarget,proto3" json:"link_target,omitempty"`
+	Uid                  uint32   `protobuf:"varint,7,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid                  uint32   `protobuf:"varint,8,opt,name=gid,proto3" json:"gid,omitempty"`
+	Dev                  uint64   `protobuf:"varint,9,opt,name=dev,proto3" json:"dev,omitempty"`
+	Ino                  uint64   `protobuf:"varint,10,opt,name=ino,proto3" json:"ino,omitempty"`
+	Nlink                uint64   `protobuf:"varint,11,opt,name=nlink,proto3" json:"nlink,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -270,8 +393,64 @@ func (m *StatResponse) GetLastModified() int64 {
 	return 0
 }
 
+func (m *StatResponse) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *StatResponse) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+func (m *StatResponse) GetLinkTarget() string {
+	if m != nil {
+		return m.LinkTarget
+	}
+	return ""
+}
+
+func (m *StatResponse) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *StatResponse) GetGid() uint32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+func (m *StatResponse) GetDev() uint64 {
+	if m != nil {
+		return m.Dev
+	}
+	return 0
+}
+
+func (m *StatResponse) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+func (m *StatResponse) GetNlink() uint64 {
+	if m != nil {
+		return m.Nlink
+	}
+	return 0
+}
+
 type ReadRequest struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
 	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -304,11 +483,11 @@ func (m *ReadRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
-func (m *ReadRequest) GetId() int64 {
+func (m *ReadRequest) GetId() string {
 	if m != nil {
 		return m.Id
 	}
-	return 0
+	return ""
 }
 
 func (m *ReadRequest) GetOffset() int64 {
@@ -327,6 +506,7 @@ func (m *ReadRequest) GetSize() int64 {
 
 type CreateRequest struct {
 	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Atomic               bool     `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -364,8 +544,15 @@ func (m *CreateRequest) GetFilename() string {
 	return ""
 }
 
+func (m *CreateRequest) GetAtomic() bool {
+	if m != nil {
+		return m.Atomic
+	}
+	return false
+}
+
 type CreateResponse struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	Result               bool     `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -397,11 +584,11 @@ func (m *CreateResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
 
-func (m *CreateResponse) GetId() int64 {
+func (m *CreateResponse) GetId() string {
 	if m != nil {
 		return m.Id
 	}
-	return 0
+	return ""
 }
 
 func (m *CreateResponse) GetResult() bool {
@@ -412,9 +599,10 @@ func (m *CreateResponse) GetResult() bool {
 }
 
 type WriteRequest struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
 	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Checksum             []byte   `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -445,11 +633,11 @@ func (m *WriteRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_WriteRequest proto.InternalMessageInfo
 
-func (m *WriteRequest) GetId() int64 {
+func (m *WriteRequest) GetId() string {
 	if m != nil {
 		return m.Id
 	}
-	return 0
+	return ""
 }
 
 func (m *WriteRequest) GetOffset() int64 {
@@ -466,6 +654,13 @@ func (m *WriteRequest) GetData() []byte {
 	return nil
 }
 
+func (m *WriteRequest) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
 type WriteResponse struct {
 	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -509,6 +704,7 @@ type ReadResponse struct {
 	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	Eof                  bool     `protobuf:"varint,3,opt,name=eof,proto3" json:"eof,omitempty"`
+	Checksum             []byte   `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -560,8 +756,15 @@ func (m *ReadResponse) GetEof() bool {
 	return false
 }
 
+func (m *ReadResponse) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
 type GetRequest struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	BlockId              int64    `protobuf:"varint,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -593,11 +796,11 @@ func (m *GetRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetRequest proto.InternalMessageInfo
 
-func (m *GetRequest) GetId() int64 {
+func (m *GetRequest) GetId() string {
 	if m != nil {
 		return m.Id
 	}
-	return 0
+	return ""
 }
 
 func (m *GetRequest) GetBlockId() int64 {
@@ -662,7 +865,2131 @@ func (m *GetResponse) GetData() []byte {
 	return nil
 }
 
+type ListRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit                int64    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Recursive            bool     `protobuf:"varint,4,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{14}
+}
+
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *ListRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+type ListResponse struct {
+	Files                []*FileInfo `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Eof                  bool        `protobuf:"varint,2,opt,name=eof,proto3" json:"eof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{15}
+}
+
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
+}
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+}
+func (m *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(m, src)
+}
+func (m *ListResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResponse.Size(m)
+}
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+
+func (m *ListResponse) GetFiles() []*FileInfo {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ListResponse) GetEof() bool {
+	if m != nil {
+		return m.Eof
+	}
+	return false
+}
+
+type FileInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Mode                 uint32   `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	LastModified         int64    `protobuf:"varint,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	ModTime              int64    `protobuf:"varint,5,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileInfo) Reset()         { *m = FileInfo{} }
+func (m *FileInfo) String() string { return proto.CompactTextString(m) }
+func (*FileInfo) ProtoMessage()    {}
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{16}
+}
+
+func (m *FileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+}
+func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+}
+func (m *FileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileInfo.Merge(m, src)
+}
+func (m *FileInfo) XXX_Size() int {
+	return xxx_messageInfo_FileInfo.Size(m)
+}
+func (m *FileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+
+func (m *FileInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileInfo) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileInfo) GetLastModified() int64 {
+	if m != nil {
+		return m.LastModified
+	}
+	return 0
+}
+
+func (m *FileInfo) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+type RemoveRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveRequest) Reset()         { *m = RemoveRequest{} }
+func (m *RemoveRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveRequest) ProtoMessage()    {}
+func (*RemoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{17}
+}
+
+func (m *RemoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveRequest.Unmarshal(m, b)
+}
+func (m *RemoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveRequest.Merge(m, src)
+}
+func (m *RemoveRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveRequest.Size(m)
+}
+func (m *RemoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveRequest proto.InternalMessageInfo
+
+func (m *RemoveRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+type RemoveResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveResponse) Reset()         { *m = RemoveResponse{} }
+func (m *RemoveResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveResponse) ProtoMessage()    {}
+func (*RemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{18}
+}
+
+func (m *RemoveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveResponse.Unmarshal(m, b)
+}
+func (m *RemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveResponse.Merge(m, src)
+}
+func (m *RemoveResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveResponse.Size(m)
+}
+func (m *RemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveResponse proto.InternalMessageInfo
+
+type RemoveAllRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveAllRequest) Reset()         { *m = RemoveAllRequest{} }
+func (m *RemoveAllRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveAllRequest) ProtoMessage()    {}
+func (*RemoveAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{19}
+}
+
+func (m *RemoveAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveAllRequest.Unmarshal(m, b)
+}
+func (m *RemoveAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveAllRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveAllRequest.Merge(m, src)
+}
+func (m *RemoveAllRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveAllRequest.Size(m)
+}
+func (m *RemoveAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveAllRequest proto.InternalMessageInfo
+
+func (m *RemoveAllRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+type RemoveAllResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveAllResponse) Reset()         { *m = RemoveAllResponse{} }
+func (m *RemoveAllResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveAllResponse) ProtoMessage()    {}
+func (*RemoveAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{20}
+}
+
+func (m *RemoveAllResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveAllResponse.Unmarshal(m, b)
+}
+func (m *RemoveAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveAllResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveAllResponse.Merge(m, src)
+}
+func (m *RemoveAllResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveAllResponse.Size(m)
+}
+func (m *RemoveAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveAllResponse proto.InternalMessageInfo
+
+type RenameRequest struct {
+	Oldname              string   `protobuf:"bytes,1,opt,name=oldname,proto3" json:"oldname,omitempty"`
+	Newname              string   `protobuf:"bytes,2,opt,name=newname,proto3" json:"newname,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameRequest) Reset()         { *m = RenameRequest{} }
+func (m *RenameRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameRequest) ProtoMessage()    {}
+func (*RenameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{21}
+}
+
+func (m *RenameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameRequest.Unmarshal(m, b)
+}
+func (m *RenameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameRequest.Marshal(b, m, deterministic)
+}
+func (m *RenameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameRequest.Merge(m, src)
+}
+func (m *RenameRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameRequest.Size(m)
+}
+func (m *RenameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameRequest proto.InternalMessageInfo
+
+func (m *RenameRequest) GetOldname() string {
+	if m != nil {
+		return m.Oldname
+	}
+	return ""
+}
+
+func (m *RenameRequest) GetNewname() string {
+	if m != nil {
+		return m.Newname
+	}
+	return ""
+}
+
+type RenameResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameResponse) Reset()         { *m = RenameResponse{} }
+func (m *RenameResponse) String() string { return proto.CompactTextString(m) }
+func (*RenameResponse) ProtoMessage()    {}
+func (*RenameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{22}
+}
+
+func (m *RenameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameResponse.Unmarshal(m, b)
+}
+func (m *RenameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameResponse.Marshal(b, m, deterministic)
+}
+func (m *RenameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameResponse.Merge(m, src)
+}
+func (m *RenameResponse) XXX_Size() int {
+	return xxx_messageInfo_RenameResponse.Size(m)
+}
+func (m *RenameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameResponse proto.InternalMessageInfo
+
+type MkdirRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Perm                 uint32   `protobuf:"varint,2,opt,name=perm,proto3" json:"perm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MkdirRequest) Reset()         { *m = MkdirRequest{} }
+func (m *MkdirRequest) String() string { return proto.CompactTextString(m) }
+func (*MkdirRequest) ProtoMessage()    {}
+func (*MkdirRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{23}
+}
+
+func (m *MkdirRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MkdirRequest.Unmarshal(m, b)
+}
+func (m *MkdirRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MkdirRequest.Marshal(b, m, deterministic)
+}
+func (m *MkdirRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MkdirRequest.Merge(m, src)
+}
+func (m *MkdirRequest) XXX_Size() int {
+	return xxx_messageInfo_MkdirRequest.Size(m)
+}
+func (m *MkdirRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MkdirRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MkdirRequest proto.InternalMessageInfo
+
+func (m *MkdirRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *MkdirRequest) GetPerm() uint32 {
+	if m != nil {
+		return m.Perm
+	}
+	return 0
+}
+
+type MkdirResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MkdirResponse) Reset()         { *m = MkdirResponse{} }
+func (m *MkdirResponse) String() string { return proto.CompactTextString(m) }
+func (*MkdirResponse) ProtoMessage()    {}
+func (*MkdirResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{24}
+}
+
+func (m *MkdirResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MkdirResponse.Unmarshal(m, b)
+}
+func (m *MkdirResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MkdirResponse.Marshal(b, m, deterministic)
+}
+func (m *MkdirResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MkdirResponse.Merge(m, src)
+}
+func (m *MkdirResponse) XXX_Size() int {
+	return xxx_messageInfo_MkdirResponse.Size(m)
+}
+func (m *MkdirResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MkdirResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MkdirResponse proto.InternalMessageInfo
+
+type MkdirAllRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Perm                 uint32   `protobuf:"varint,2,opt,name=perm,proto3" json:"perm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MkdirAllRequest) Reset()         { *m = MkdirAllRequest{} }
+func (m *MkdirAllRequest) String() string { return proto.CompactTextString(m) }
+func (*MkdirAllRequest) ProtoMessage()    {}
+func (*MkdirAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{25}
+}
+
+func (m *MkdirAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MkdirAllRequest.Unmarshal(m, b)
+}
+func (m *MkdirAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MkdirAllRequest.Marshal(b, m, deterministic)
+}
+func (m *MkdirAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MkdirAllRequest.Merge(m, src)
+}
+func (m *MkdirAllRequest) XXX_Size() int {
+	return xxx_messageInfo_MkdirAllRequest.Size(m)
+}
+func (m *MkdirAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MkdirAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MkdirAllRequest proto.InternalMessageInfo
+
+func (m *MkdirAllRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *MkdirAllRequest) GetPerm() uint32 {
+	if m != nil {
+		return m.Perm
+	}
+	return 0
+}
+
+type MkdirAllResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MkdirAllResponse) Reset()         { *m = MkdirAllResponse{} }
+func (m *MkdirAllResponse) String() string { return proto.CompactTextString(m) }
+func (*MkdirAllResponse) ProtoMessage()    {}
+func (*MkdirAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{26}
+}
+
+func (m *MkdirAllResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MkdirAllResponse.Unmarshal(m, b)
+}
+func (m *MkdirAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MkdirAllResponse.Marshal(b, m, deterministic)
+}
+func (m *MkdirAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MkdirAllResponse.Merge(m, src)
+}
+func (m *MkdirAllResponse) XXX_Size() int {
+	return xxx_messageInfo_MkdirAllResponse.Size(m)
+}
+func (m *MkdirAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MkdirAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MkdirAllResponse proto.InternalMessageInfo
+
+type FeaturesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeaturesRequest) Reset()         { *m = FeaturesRequest{} }
+func (m *FeaturesRequest) String() string { return proto.CompactTextString(m) }
+func (*FeaturesRequest) ProtoMessage()    {}
+func (*FeaturesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{27}
+}
+
+func (m *FeaturesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeaturesRequest.Unmarshal(m, b)
+}
+func (m *FeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeaturesRequest.Marshal(b, m, deterministic)
+}
+func (m *FeaturesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeaturesRequest.Merge(m, src)
+}
+func (m *FeaturesRequest) XXX_Size() int {
+	return xxx_messageInfo_FeaturesRequest.Size(m)
+}
+func (m *FeaturesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeaturesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeaturesRequest proto.InternalMessageInfo
+
+type FeaturesResponse struct {
+	Features             []string `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeaturesResponse) Reset()         { *m = FeaturesResponse{} }
+func (m *FeaturesResponse) String() string { return proto.CompactTextString(m) }
+func (*FeaturesResponse) ProtoMessage()    {}
+func (*FeaturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{28}
+}
+
+func (m *FeaturesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeaturesResponse.Unmarshal(m, b)
+}
+func (m *FeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeaturesResponse.Marshal(b, m, deterministic)
+}
+func (m *FeaturesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeaturesResponse.Merge(m, src)
+}
+func (m *FeaturesResponse) XXX_Size() int {
+	return xxx_messageInfo_FeaturesResponse.Size(m)
+}
+func (m *FeaturesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeaturesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeaturesResponse proto.InternalMessageInfo
+
+func (m *FeaturesResponse) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type ReadStreamRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length               int64    `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	ChunkSize            int64    `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadStreamRequest) Reset()         { *m = ReadStreamRequest{} }
+func (m *ReadStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadStreamRequest) ProtoMessage()    {}
+func (*ReadStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{29}
+}
+
+func (m *ReadStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadStreamRequest.Unmarshal(m, b)
+}
+func (m *ReadStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadStreamRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadStreamRequest.Merge(m, src)
+}
+func (m *ReadStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadStreamRequest.Size(m)
+}
+func (m *ReadStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadStreamRequest proto.InternalMessageInfo
+
+func (m *ReadStreamRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *ReadStreamRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadStreamRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *ReadStreamRequest) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+type ReadStreamResponse struct {
+	Offset               int64    `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Eof                  bool     `protobuf:"varint,3,opt,name=eof,proto3" json:"eof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadStreamResponse) Reset()         { *m = ReadStreamResponse{} }
+func (m *ReadStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadStreamResponse) ProtoMessage()    {}
+func (*ReadStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{30}
+}
+
+func (m *ReadStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadStreamResponse.Unmarshal(m, b)
+}
+func (m *ReadStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadStreamResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadStreamResponse.Merge(m, src)
+}
+func (m *ReadStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadStreamResponse.Size(m)
+}
+func (m *ReadStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadStreamResponse proto.InternalMessageInfo
+
+func (m *ReadStreamResponse) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadStreamResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReadStreamResponse) GetEof() bool {
+	if m != nil {
+		return m.Eof
+	}
+	return false
+}
+
+type WriteStreamHeader struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Mode                 uint32   `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteStreamHeader) Reset()         { *m = WriteStreamHeader{} }
+func (m *WriteStreamHeader) String() string { return proto.CompactTextString(m) }
+func (*WriteStreamHeader) ProtoMessage()    {}
+func (*WriteStreamHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{31}
+}
+
+func (m *WriteStreamHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteStreamHeader.Unmarshal(m, b)
+}
+func (m *WriteStreamHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteStreamHeader.Marshal(b, m, deterministic)
+}
+func (m *WriteStreamHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteStreamHeader.Merge(m, src)
+}
+func (m *WriteStreamHeader) XXX_Size() int {
+	return xxx_messageInfo_WriteStreamHeader.Size(m)
+}
+func (m *WriteStreamHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteStreamHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteStreamHeader proto.InternalMessageInfo
+
+func (m *WriteStreamHeader) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *WriteStreamHeader) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *WriteStreamHeader) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+type WriteStreamRequest struct {
+	Header               *WriteStreamHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data                 []byte             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Eof                  bool               `protobuf:"varint,3,opt,name=eof,proto3" json:"eof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *WriteStreamRequest) Reset()         { *m = WriteStreamRequest{} }
+func (m *WriteStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteStreamRequest) ProtoMessage()    {}
+func (*WriteStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{32}
+}
+
+func (m *WriteStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteStreamRequest.Unmarshal(m, b)
+}
+func (m *WriteStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteStreamRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteStreamRequest.Merge(m, src)
+}
+func (m *WriteStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteStreamRequest.Size(m)
+}
+func (m *WriteStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteStreamRequest proto.InternalMessageInfo
+
+func (m *WriteStreamRequest) GetHeader() *WriteStreamHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *WriteStreamRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *WriteStreamRequest) GetEof() bool {
+	if m != nil {
+		return m.Eof
+	}
+	return false
+}
+
+type WriteStreamResponse struct {
+	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum             string   `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteStreamResponse) Reset()         { *m = WriteStreamResponse{} }
+func (m *WriteStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteStreamResponse) ProtoMessage()    {}
+func (*WriteStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{33}
+}
+
+func (m *WriteStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteStreamResponse.Unmarshal(m, b)
+}
+func (m *WriteStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteStreamResponse.Marshal(b, m, deterministic)
+}
+func (m *WriteStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteStreamResponse.Merge(m, src)
+}
+func (m *WriteStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteStreamResponse.Size(m)
+}
+func (m *WriteStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteStreamResponse proto.InternalMessageInfo
+
+func (m *WriteStreamResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *WriteStreamResponse) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+type ChecksumRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	BlockSize            int64    `protobuf:"varint,2,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	Blocks               bool     `protobuf:"varint,3,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChecksumRequest) Reset()         { *m = ChecksumRequest{} }
+func (m *ChecksumRequest) String() string { return proto.CompactTextString(m) }
+func (*ChecksumRequest) ProtoMessage()    {}
+func (*ChecksumRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{34}
+}
+
+func (m *ChecksumRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChecksumRequest.Unmarshal(m, b)
+}
+func (m *ChecksumRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChecksumRequest.Marshal(b, m, deterministic)
+}
+func (m *ChecksumRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChecksumRequest.Merge(m, src)
+}
+func (m *ChecksumRequest) XXX_Size() int {
+	return xxx_messageInfo_ChecksumRequest.Size(m)
+}
+func (m *ChecksumRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChecksumRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChecksumRequest proto.InternalMessageInfo
+
+func (m *ChecksumRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *ChecksumRequest) GetBlockSize() int64 {
+	if m != nil {
+		return m.BlockSize
+	}
+	return 0
+}
+
+func (m *ChecksumRequest) GetBlocks() bool {
+	if m != nil {
+		return m.Blocks
+	}
+	return false
+}
+
+type ChecksumResponse struct {
+	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum             string   `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Blocks               []uint32 `protobuf:"varint,3,rep,packed,name=blocks,proto3" json:"blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChecksumResponse) Reset()         { *m = ChecksumResponse{} }
+func (m *ChecksumResponse) String() string { return proto.CompactTextString(m) }
+func (*ChecksumResponse) ProtoMessage()    {}
+func (*ChecksumResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{35}
+}
+
+func (m *ChecksumResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChecksumResponse.Unmarshal(m, b)
+}
+func (m *ChecksumResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChecksumResponse.Marshal(b, m, deterministic)
+}
+func (m *ChecksumResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChecksumResponse.Merge(m, src)
+}
+func (m *ChecksumResponse) XXX_Size() int {
+	return xxx_messageInfo_ChecksumResponse.Size(m)
+}
+func (m *ChecksumResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChecksumResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChecksumResponse proto.InternalMessageInfo
+
+func (m *ChecksumResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ChecksumResponse) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *ChecksumResponse) GetBlocks() []uint32 {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+type UploadBeginRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Mode                 uint32   `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Parent               string   `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+	Part                 int32    `protobuf:"varint,5,opt,name=part,proto3" json:"part,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadBeginRequest) Reset()         { *m = UploadBeginRequest{} }
+func (m *UploadBeginRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadBeginRequest) ProtoMessage()    {}
+func (*UploadBeginRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{36}
+}
+
+func (m *UploadBeginRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadBeginRequest.Unmarshal(m, b)
+}
+func (m *UploadBeginRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadBeginRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadBeginRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadBeginRequest.Merge(m, src)
+}
+func (m *UploadBeginRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadBeginRequest.Size(m)
+}
+func (m *UploadBeginRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadBeginRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadBeginRequest proto.InternalMessageInfo
+
+func (m *UploadBeginRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *UploadBeginRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *UploadBeginRequest) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *UploadBeginRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *UploadBeginRequest) GetPart() int32 {
+	if m != nil {
+		return m.Part
+	}
+	return 0
+}
+
+type UploadBeginResponse struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadBeginResponse) Reset()         { *m = UploadBeginResponse{} }
+func (m *UploadBeginResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadBeginResponse) ProtoMessage()    {}
+func (*UploadBeginResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{37}
+}
+
+func (m *UploadBeginResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadBeginResponse.Unmarshal(m, b)
+}
+func (m *UploadBeginResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadBeginResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadBeginResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadBeginResponse.Merge(m, src)
+}
+func (m *UploadBeginResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadBeginResponse.Size(m)
+}
+func (m *UploadBeginResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadBeginResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadBeginResponse proto.InternalMessageInfo
+
+func (m *UploadBeginResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UploadAppendRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Checksum             []byte   `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadAppendRequest) Reset()         { *m = UploadAppendRequest{} }
+func (m *UploadAppendRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadAppendRequest) ProtoMessage()    {}
+func (*UploadAppendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{38}
+}
+
+func (m *UploadAppendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAppendRequest.Unmarshal(m, b)
+}
+func (m *UploadAppendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAppendRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadAppendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAppendRequest.Merge(m, src)
+}
+func (m *UploadAppendRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadAppendRequest.Size(m)
+}
+func (m *UploadAppendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAppendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAppendRequest proto.InternalMessageInfo
+
+func (m *UploadAppendRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UploadAppendRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *UploadAppendRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *UploadAppendRequest) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
+type UploadAppendResponse struct {
+	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadAppendResponse) Reset()         { *m = UploadAppendResponse{} }
+func (m *UploadAppendResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadAppendResponse) ProtoMessage()    {}
+func (*UploadAppendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{39}
+}
+
+func (m *UploadAppendResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAppendResponse.Unmarshal(m, b)
+}
+func (m *UploadAppendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAppendResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadAppendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAppendResponse.Merge(m, src)
+}
+func (m *UploadAppendResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadAppendResponse.Size(m)
+}
+func (m *UploadAppendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAppendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAppendResponse proto.InternalMessageInfo
+
+func (m *UploadAppendResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type Range struct {
+	Offset               int64    `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length               int64    `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Range) Reset()         { *m = Range{} }
+func (m *Range) String() string { return proto.CompactTextString(m) }
+func (*Range) ProtoMessage()    {}
+func (*Range) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{40}
+}
+
+func (m *Range) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Range.Unmarshal(m, b)
+}
+func (m *Range) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Range.Marshal(b, m, deterministic)
+}
+func (m *Range) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Range.Merge(m, src)
+}
+func (m *Range) XXX_Size() int {
+	return xxx_messageInfo_Range.Size(m)
+}
+func (m *Range) XXX_DiscardUnknown() {
+	xxx_messageInfo_Range.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Range proto.InternalMessageInfo
+
+func (m *Range) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *Range) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+type UploadStatusRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadStatusRequest) Reset()         { *m = UploadStatusRequest{} }
+func (m *UploadStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadStatusRequest) ProtoMessage()    {}
+func (*UploadStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{41}
+}
+
+func (m *UploadStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadStatusRequest.Unmarshal(m, b)
+}
+func (m *UploadStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadStatusRequest.Merge(m, src)
+}
+func (m *UploadStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadStatusRequest.Size(m)
+}
+func (m *UploadStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadStatusRequest proto.InternalMessageInfo
+
+func (m *UploadStatusRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UploadStatusResponse struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Ranges               []*Range `protobuf:"bytes,3,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadStatusResponse) Reset()         { *m = UploadStatusResponse{} }
+func (m *UploadStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadStatusResponse) ProtoMessage()    {}
+func (*UploadStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{42}
+}
+
+func (m *UploadStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadStatusResponse.Unmarshal(m, b)
+}
+func (m *UploadStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadStatusResponse.Merge(m, src)
+}
+func (m *UploadStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadStatusResponse.Size(m)
+}
+func (m *UploadStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadStatusResponse proto.InternalMessageInfo
+
+func (m *UploadStatusResponse) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *UploadStatusResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *UploadStatusResponse) GetRanges() []*Range {
+	if m != nil {
+		return m.Ranges
+	}
+	return nil
+}
+
+type UploadPart struct {
+	Part                 int32    `protobuf:"varint,1,opt,name=part,proto3" json:"part,omitempty"`
+	Md5                  string   `protobuf:"bytes,2,opt,name=md5,proto3" json:"md5,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadPart) Reset()         { *m = UploadPart{} }
+func (m *UploadPart) String() string { return proto.CompactTextString(m) }
+func (*UploadPart) ProtoMessage()    {}
+func (*UploadPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{43}
+}
+
+func (m *UploadPart) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadPart.Unmarshal(m, b)
+}
+func (m *UploadPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadPart.Marshal(b, m, deterministic)
+}
+func (m *UploadPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadPart.Merge(m, src)
+}
+func (m *UploadPart) XXX_Size() int {
+	return xxx_messageInfo_UploadPart.Size(m)
+}
+func (m *UploadPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadPart proto.InternalMessageInfo
+
+func (m *UploadPart) GetPart() int32 {
+	if m != nil {
+		return m.Part
+	}
+	return 0
+}
+
+func (m *UploadPart) GetMd5() string {
+	if m != nil {
+		return m.Md5
+	}
+	return ""
+}
+
+type UploadCommitRequest struct {
+	Id                   string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Checksum             string        `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Parts                []*UploadPart `protobuf:"bytes,3,rep,name=parts,proto3" json:"parts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *UploadCommitRequest) Reset()         { *m = UploadCommitRequest{} }
+func (m *UploadCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadCommitRequest) ProtoMessage()    {}
+func (*UploadCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{44}
+}
+
+func (m *UploadCommitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadCommitRequest.Unmarshal(m, b)
+}
+func (m *UploadCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadCommitRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadCommitRequest.Merge(m, src)
+}
+func (m *UploadCommitRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadCommitRequest.Size(m)
+}
+func (m *UploadCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadCommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadCommitRequest proto.InternalMessageInfo
+
+func (m *UploadCommitRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UploadCommitRequest) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *UploadCommitRequest) GetParts() []*UploadPart {
+	if m != nil {
+		return m.Parts
+	}
+	return nil
+}
+
+type UploadCommitResponse struct {
+	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum             string   `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Md5                  string   `protobuf:"bytes,3,opt,name=md5,proto3" json:"md5,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadCommitResponse) Reset()         { *m = UploadCommitResponse{} }
+func (m *UploadCommitResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadCommitResponse) ProtoMessage()    {}
+func (*UploadCommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{45}
+}
+
+func (m *UploadCommitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadCommitResponse.Unmarshal(m, b)
+}
+func (m *UploadCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadCommitResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadCommitResponse.Merge(m, src)
+}
+func (m *UploadCommitResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadCommitResponse.Size(m)
+}
+func (m *UploadCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadCommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadCommitResponse proto.InternalMessageInfo
+
+func (m *UploadCommitResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *UploadCommitResponse) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *UploadCommitResponse) GetMd5() string {
+	if m != nil {
+		return m.Md5
+	}
+	return ""
+}
+
+type UploadAbortRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadAbortRequest) Reset()         { *m = UploadAbortRequest{} }
+func (m *UploadAbortRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadAbortRequest) ProtoMessage()    {}
+func (*UploadAbortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{46}
+}
+
+func (m *UploadAbortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAbortRequest.Unmarshal(m, b)
+}
+func (m *UploadAbortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAbortRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadAbortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAbortRequest.Merge(m, src)
+}
+func (m *UploadAbortRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadAbortRequest.Size(m)
+}
+func (m *UploadAbortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAbortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAbortRequest proto.InternalMessageInfo
+
+func (m *UploadAbortRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UploadAbortResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadAbortResponse) Reset()         { *m = UploadAbortResponse{} }
+func (m *UploadAbortResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadAbortResponse) ProtoMessage()    {}
+func (*UploadAbortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{47}
+}
+
+func (m *UploadAbortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAbortResponse.Unmarshal(m, b)
+}
+func (m *UploadAbortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAbortResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadAbortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAbortResponse.Merge(m, src)
+}
+func (m *UploadAbortResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadAbortResponse.Size(m)
+}
+func (m *UploadAbortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAbortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAbortResponse proto.InternalMessageInfo
+
+type OpenFileRequest struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Flags                uint32   `protobuf:"varint,2,opt,name=flags,proto3" json:"flags,omitempty"`
+	Perm                 uint32   `protobuf:"varint,3,opt,name=perm,proto3" json:"perm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenFileRequest) Reset()         { *m = OpenFileRequest{} }
+func (m *OpenFileRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenFileRequest) ProtoMessage()    {}
+func (*OpenFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{48}
+}
+
+func (m *OpenFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenFileRequest.Unmarshal(m, b)
+}
+func (m *OpenFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenFileRequest.Marshal(b, m, deterministic)
+}
+func (m *OpenFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenFileRequest.Merge(m, src)
+}
+func (m *OpenFileRequest) XXX_Size() int {
+	return xxx_messageInfo_OpenFileRequest.Size(m)
+}
+func (m *OpenFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenFileRequest proto.InternalMessageInfo
+
+func (m *OpenFileRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *OpenFileRequest) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+func (m *OpenFileRequest) GetPerm() uint32 {
+	if m != nil {
+		return m.Perm
+	}
+	return 0
+}
+
+type OpenFileResponse struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenFileResponse) Reset()         { *m = OpenFileResponse{} }
+func (m *OpenFileResponse) String() string { return proto.CompactTextString(m) }
+func (*OpenFileResponse) ProtoMessage()    {}
+func (*OpenFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{49}
+}
+
+func (m *OpenFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenFileResponse.Unmarshal(m, b)
+}
+func (m *OpenFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenFileResponse.Marshal(b, m, deterministic)
+}
+func (m *OpenFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenFileResponse.Merge(m, src)
+}
+func (m *OpenFileResponse) XXX_Size() int {
+	return xxx_messageInfo_OpenFileResponse.Size(m)
+}
+func (m *OpenFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenFileResponse proto.InternalMessageInfo
+
+func (m *OpenFileResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type TruncateRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename             string   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TruncateRequest) Reset()         { *m = TruncateRequest{} }
+func (m *TruncateRequest) String() string { return proto.CompactTextString(m) }
+func (*TruncateRequest) ProtoMessage()    {}
+func (*TruncateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{50}
+}
+
+func (m *TruncateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TruncateRequest.Unmarshal(m, b)
+}
+func (m *TruncateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TruncateRequest.Marshal(b, m, deterministic)
+}
+func (m *TruncateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TruncateRequest.Merge(m, src)
+}
+func (m *TruncateRequest) XXX_Size() int {
+	return xxx_messageInfo_TruncateRequest.Size(m)
+}
+func (m *TruncateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TruncateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TruncateRequest proto.InternalMessageInfo
+
+func (m *TruncateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TruncateRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *TruncateRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type TruncateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TruncateResponse) Reset()         { *m = TruncateResponse{} }
+func (m *TruncateResponse) String() string { return proto.CompactTextString(m) }
+func (*TruncateResponse) ProtoMessage()    {}
+func (*TruncateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{51}
+}
+
+func (m *TruncateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TruncateResponse.Unmarshal(m, b)
+}
+func (m *TruncateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TruncateResponse.Marshal(b, m, deterministic)
+}
+func (m *TruncateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TruncateResponse.Merge(m, src)
+}
+func (m *TruncateResponse) XXX_Size() int {
+	return xxx_messageInfo_TruncateResponse.Size(m)
+}
+func (m *TruncateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TruncateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TruncateResponse proto.InternalMessageInfo
+
+type SyncRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename             string   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
+func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()    {}
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{52}
+}
+
+func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
+}
+func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncRequest.Merge(m, src)
+}
+func (m *SyncRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncRequest.Size(m)
+}
+func (m *SyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
+
+func (m *SyncRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SyncRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+type SyncResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncResponse) Reset()         { *m = SyncResponse{} }
+func (m *SyncResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncResponse) ProtoMessage()    {}
+func (*SyncResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{53}
+}
+
+func (m *SyncResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncResponse.Unmarshal(m, b)
+}
+func (m *SyncResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncResponse.Marshal(b, m, deterministic)
+}
+func (m *SyncResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncResponse.Merge(m, src)
+}
+func (m *SyncResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncResponse.Size(m)
+}
+func (m *SyncResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncResponse proto.InternalMessageInfo
+
+type ChmodRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename             string   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Mode                 uint32   `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChmodRequest) Reset()         { *m = ChmodRequest{} }
+func (m *ChmodRequest) String() string { return proto.CompactTextString(m) }
+func (*ChmodRequest) ProtoMessage()    {}
+func (*ChmodRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{54}
+}
+
+func (m *ChmodRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChmodRequest.Unmarshal(m, b)
+}
+func (m *ChmodRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChmodRequest.Marshal(b, m, deterministic)
+}
+func (m *ChmodRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChmodRequest.Merge(m, src)
+}
+func (m *ChmodRequest) XXX_Size() int {
+	return xxx_messageInfo_ChmodRequest.Size(m)
+}
+func (m *ChmodRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChmodRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChmodRequest proto.InternalMessageInfo
+
+func (m *ChmodRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChmodRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *ChmodRequest) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+type ChmodResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChmodResponse) Reset()         { *m = ChmodResponse{} }
+func (m *ChmodResponse) String() string { return proto.CompactTextString(m) }
+func (*ChmodResponse) ProtoMessage()    {}
+func (*ChmodResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{55}
+}
+
+func (m *ChmodResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChmodResponse.Unmarshal(m, b)
+}
+func (m *ChmodResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChmodResponse.Marshal(b, m, deterministic)
+}
+func (m *ChmodResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChmodResponse.Merge(m, src)
+}
+func (m *ChmodResponse) XXX_Size() int {
+	return xxx_messageInfo_ChmodResponse.Size(m)
+}
+func (m *ChmodResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChmodResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChmodResponse proto.InternalMessageInfo
+
+type ChownRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename             string   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Uid                  int32    `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid                  int32    `protobuf:"varint,4,opt,name=gid,proto3" json:"gid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChownRequest) Reset()         { *m = ChownRequest{} }
+func (m *ChownRequest) String() string { return proto.CompactTextString(m) }
+func (*ChownRequest) ProtoMessage()    {}
+func (*ChownRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{56}
+}
+
+func (m *ChownRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChownRequest.Unmarshal(m, b)
+}
+func (m *ChownRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChownRequest.Marshal(b, m, deterministic)
+}
+func (m *ChownRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChownRequest.Merge(m, src)
+}
+func (m *ChownRequest) XXX_Size() int {
+	return xxx_messageInfo_ChownRequest.Size(m)
+}
+func (m *ChownRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChownRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChownRequest proto.InternalMessageInfo
+
+func (m *ChownRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChownRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *ChownRequest) GetUid() int32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *ChownRequest) GetGid() int32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+type ChownResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChownResponse) Reset()         { *m = ChownResponse{} }
+func (m *ChownResponse) String() string { return proto.CompactTextString(m) }
+func (*ChownResponse) ProtoMessage()    {}
+func (*ChownResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{57}
+}
+
+func (m *ChownResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChownResponse.Unmarshal(m, b)
+}
+func (m *ChownResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChownResponse.Marshal(b, m, deterministic)
+}
+func (m *ChownResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChownResponse.Merge(m, src)
+}
+func (m *ChownResponse) XXX_Size() int {
+	return xxx_messageInfo_ChownResponse.Size(m)
+}
+func (m *ChownResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChownResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChownResponse proto.InternalMessageInfo
+
+type ChtimesRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename             string   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Atime                int64    `protobuf:"varint,3,opt,name=atime,proto3" json:"atime,omitempty"`
+	Mtime                int64    `protobuf:"varint,4,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChtimesRequest) Reset()         { *m = ChtimesRequest{} }
+func (m *ChtimesRequest) String() string { return proto.CompactTextString(m) }
+func (*ChtimesRequest) ProtoMessage()    {}
+func (*ChtimesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{58}
+}
+
+func (m *ChtimesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChtimesRequest.Unmarshal(m, b)
+}
+func (m *ChtimesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChtimesRequest.Marshal(b, m, deterministic)
+}
+func (m *ChtimesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChtimesRequest.Merge(m, src)
+}
+func (m *ChtimesRequest) XXX_Size() int {
+	return xxx_messageInfo_ChtimesRequest.Size(m)
+}
+func (m *ChtimesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChtimesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChtimesRequest proto.InternalMessageInfo
+
+func (m *ChtimesRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChtimesRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *ChtimesRequest) GetAtime() int64 {
+	if m != nil {
+		return m.Atime
+	}
+	return 0
+}
+
+func (m *ChtimesRequest) GetMtime() int64 {
+	if m != nil {
+		return m.Mtime
+	}
+	return 0
+}
+
+type ChtimesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChtimesResponse) Reset()         { *m = ChtimesResponse{} }
+func (m *ChtimesResponse) String() string { return proto.CompactTextString(m) }
+func (*ChtimesResponse) ProtoMessage()    {}
+func (*ChtimesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4090a8107f0dd06, []int{59}
+}
+
+func (m *ChtimesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChtimesResponse.Unmarshal(m, b)
+}
+func (m *ChtimesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChtimesResponse.Marshal(b, m, deterministic)
+}
+func (m *ChtimesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChtimesResponse.Merge(m, src)
+}
+func (m *ChtimesResponse) XXX_Size() int {
+	return xxx_messageInfo_ChtimesResponse.Size(m)
+}
+func (m *ChtimesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChtimesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChtimesResponse proto.InternalMessageInfo
+
 func init() {
+	proto.RegisterEnum("OpenFlag", OpenFlag_name, OpenFlag_value)
+	proto.RegisterEnum("ErrorCode", ErrorCode_name, ErrorCode_value)
 	proto.RegisterType((*OpenRequest)(nil), "OpenRequest")
 	proto.RegisterType((*OpenResponse)(nil), "OpenResponse")
 	proto.RegisterType((*CloseRequest)(nil), "CloseRequest")
@@ -677,38 +3004,178 @@ func init() {
 	proto.RegisterType((*ReadResponse)(nil), "ReadResponse")
 	proto.RegisterType((*GetRequest)(nil), "GetRequest")
 	proto.RegisterType((*GetResponse)(nil), "GetResponse")
+	proto.RegisterType((*ListRequest)(nil), "ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "ListResponse")
+	proto.RegisterType((*FileInfo)(nil), "FileInfo")
+	proto.RegisterType((*RemoveRequest)(nil), "RemoveRequest")
+	proto.RegisterType((*RemoveResponse)(nil), "RemoveResponse")
+	proto.RegisterType((*RemoveAllRequest)(nil), "RemoveAllRequest")
+	proto.RegisterType((*RemoveAllResponse)(nil), "RemoveAllResponse")
+	proto.RegisterType((*RenameRequest)(nil), "RenameRequest")
+	proto.RegisterType((*RenameResponse)(nil), "RenameResponse")
+	proto.RegisterType((*MkdirRequest)(nil), "MkdirRequest")
+	proto.RegisterType((*MkdirResponse)(nil), "MkdirResponse")
+	proto.RegisterType((*MkdirAllRequest)(nil), "MkdirAllRequest")
+	proto.RegisterType((*MkdirAllResponse)(nil), "MkdirAllResponse")
+	proto.RegisterType((*FeaturesRequest)(nil), "FeaturesRequest")
+	proto.RegisterType((*FeaturesResponse)(nil), "FeaturesResponse")
+	proto.RegisterType((*ReadStreamRequest)(nil), "ReadStreamRequest")
+	proto.RegisterType((*ReadStreamResponse)(nil), "ReadStreamResponse")
+	proto.RegisterType((*WriteStreamHeader)(nil), "WriteStreamHeader")
+	proto.RegisterType((*WriteStreamRequest)(nil), "WriteStreamRequest")
+	proto.RegisterType((*WriteStreamResponse)(nil), "WriteStreamResponse")
+	proto.RegisterType((*ChecksumRequest)(nil), "ChecksumRequest")
+	proto.RegisterType((*ChecksumResponse)(nil), "ChecksumResponse")
+	proto.RegisterType((*UploadBeginRequest)(nil), "UploadBeginRequest")
+	proto.RegisterType((*UploadBeginResponse)(nil), "UploadBeginResponse")
+	proto.RegisterType((*UploadAppendRequest)(nil), "UploadAppendRequest")
+	proto.RegisterType((*UploadAppendResponse)(nil), "UploadAppendResponse")
+	proto.RegisterType((*Range)(nil), "Range")
+	proto.RegisterType((*UploadStatusRequest)(nil), "UploadStatusRequest")
+	proto.RegisterType((*UploadStatusResponse)(nil), "UploadStatusResponse")
+	proto.RegisterType((*UploadPart)(nil), "UploadPart")
+	proto.RegisterType((*UploadCommitRequest)(nil), "UploadCommitRequest")
+	proto.RegisterType((*UploadCommitResponse)(nil), "UploadCommitResponse")
+	proto.RegisterType((*UploadAbortRequest)(nil), "UploadAbortRequest")
+	proto.RegisterType((*UploadAbortResponse)(nil), "UploadAbortResponse")
+	proto.RegisterType((*OpenFileRequest)(nil), "OpenFileRequest")
+	proto.RegisterType((*OpenFileResponse)(nil), "OpenFileResponse")
+	proto.RegisterType((*TruncateRequest)(nil), "TruncateRequest")
+	proto.RegisterType((*TruncateResponse)(nil), "TruncateResponse")
+	proto.RegisterType((*SyncRequest)(nil), "SyncRequest")
+	proto.RegisterType((*SyncResponse)(nil), "SyncResponse")
+	proto.RegisterType((*ChmodRequest)(nil), "ChmodRequest")
+	proto.RegisterType((*ChmodResponse)(nil), "ChmodResponse")
+	proto.RegisterType((*ChownRequest)(nil), "ChownRequest")
+	proto.RegisterType((*ChownResponse)(nil), "ChownResponse")
+	proto.RegisterType((*ChtimesRequest)(nil), "ChtimesRequest")
+	proto.RegisterType((*ChtimesResponse)(nil), "ChtimesResponse")
 }
 
 func init() { proto.RegisterFile("proto/file.proto", fileDescriptor_e4090a8107f0dd06) }
 
 var fileDescriptor_e4090a8107f0dd06 = []byte{
-	// 448 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x41, 0x8b, 0x13, 0x31,
-	0x14, 0xee, 0x74, 0x6a, 0xad, 0xaf, 0xc9, 0xec, 0x92, 0x83, 0xd4, 0x39, 0x48, 0xc9, 0x22, 0x54,
-	0x16, 0x22, 0x28, 0xa8, 0xf7, 0x05, 0x75, 0x05, 0x51, 0xe2, 0xc1, 0x83, 0x87, 0x65, 0xd6, 0xbc,
-	0x81, 0xe0, 0x6c, 0x53, 0x27, 0xd9, 0x83, 0xfe, 0x76, 0x0f, 0x92, 0x4c, 0xa6, 0x93, 0x11, 0x0b,
-	0x75, 0x6f, 0xef, 0x25, 0xdf, 0xbc, 0xf7, 0xbd, 0x2f, 0xdf, 0x1b, 0x38, 0xdd, 0xb5, 0xc6, 0x99,
-	0x67, 0xb5, 0x6e, 0x50, 0x84, 0x90, 0x3f, 0x85, 0xe5, 0xc7, 0x1d, 0x6e, 0x25, 0xfe, 0xb8, 0x45,
-	0xeb, 0x58, 0x09, 0x0b, 0x7f, 0xb9, 0xad, 0x6e, 0x70, 0x95, 0xad, 0xb3, 0xcd, 0x03, 0xb9, 0xcf,
-	0xf9, 0x4b, 0x20, 0x1d, 0xd4, 0xee, 0xcc, 0xd6, 0x22, 0x2b, 0x60, 0xaa, 0x55, 0x40, 0xe5, 0x72,
-	0xaa, 0x15, 0x7b, 0x08, 0xf3, 0x16, 0xed, 0x6d, 0xe3, 0x56, 0xd3, 0x75, 0xb6, 0x59, 0xc8, 0x98,
-	0xf1, 0xc7, 0x40, 0x2e, 0x1a, 0x63, 0xb1, 0xef, 0xf1, 0xd7, 0x77, 0xfc, 0x04, 0x68, 0xbc, 0xef,
-	0x0a, 0x7b, 0x4e, 0x9f, 0x5d, 0xe5, 0x8e, 0xe1, 0xf4, 0x15, 0x48, 0x07, 0x8d, 0x9c, 0x18, 0xcc,
-	0xdc, 0xcf, 0x5d, 0x8f, 0x0b, 0xb1, 0x3f, 0xb3, 0xfa, 0x17, 0x06, 0x56, 0xb9, 0x0c, 0x31, 0x3b,
-	0x03, 0xda, 0x54, 0xd6, 0x5d, 0xdd, 0x18, 0xa5, 0x6b, 0x8d, 0x6a, 0x95, 0x87, 0x4b, 0xe2, 0x0f,
-	0x3f, 0xc4, 0x33, 0x7e, 0x09, 0x4b, 0x89, 0x95, 0x3a, 0xc0, 0xdb, 0xcf, 0x6b, 0xea, 0xda, 0xa2,
-	0x8b, 0x95, 0x63, 0xb6, 0xef, 0x97, 0x0f, 0xfd, 0xf8, 0x39, 0xd0, 0x8b, 0x16, 0x2b, 0x87, 0xc7,
-	0x0c, 0xf5, 0x1a, 0x8a, 0x1e, 0xfc, 0x9f, 0x52, 0xbf, 0x07, 0xf2, 0xa5, 0xd5, 0x0e, 0xef, 0x40,
-	0x59, 0x55, 0xae, 0x5a, 0xcd, 0xd6, 0xd9, 0x86, 0xc8, 0x10, 0xf3, 0x33, 0xa0, 0xb1, 0xd6, 0xa0,
-	0x6d, 0x98, 0x2b, 0x4b, 0xe6, 0x7a, 0x07, 0xa4, 0x93, 0xe8, 0x30, 0x66, 0x5f, 0x7c, 0x3a, 0x14,
-	0x67, 0xa7, 0x90, 0xa3, 0xa9, 0x83, 0x44, 0x0b, 0xe9, 0x43, 0xfe, 0x0a, 0xe0, 0x2d, 0xba, 0x43,
-	0xc4, 0x1f, 0xc1, 0xe2, 0xba, 0x31, 0xdf, 0xbe, 0x5f, 0x69, 0x15, 0xa9, 0xdf, 0x0f, 0xf9, 0xa5,
-	0xe2, 0x9f, 0x60, 0x19, 0x3e, 0x8c, 0x0c, 0x52, 0x64, 0x36, 0x42, 0xfe, 0xd3, 0x08, 0x3d, 0xb9,
-	0x7c, 0x20, 0xf7, 0xfc, 0x77, 0x06, 0xb3, 0x37, 0xba, 0x41, 0xf6, 0x04, 0x66, 0xde, 0xf1, 0x8c,
-	0x88, 0x64, 0x47, 0x4a, 0x2a, 0xd2, 0x35, 0xe0, 0x13, 0x0f, 0xf3, 0x26, 0x64, 0x44, 0x24, 0xb6,
-	0x2d, 0xa9, 0x48, 0x9d, 0xd9, 0xc1, 0xbc, 0x56, 0x8c, 0x88, 0xc4, 0x55, 0x25, 0x15, 0xa9, 0x80,
-	0x7c, 0xc2, 0x36, 0x70, 0x2f, 0xac, 0x03, 0xa3, 0x22, 0x5d, 0x9b, 0xb2, 0x10, 0xe3, 0x2d, 0x99,
-	0xb0, 0x73, 0x98, 0x77, 0x3e, 0x61, 0x85, 0x18, 0xb9, 0xab, 0x3c, 0x11, 0x63, 0x03, 0x75, 0x65,
-	0xc3, 0x73, 0x32, 0x2a, 0x52, 0x8b, 0x94, 0x85, 0x18, 0xbd, 0x32, 0x9f, 0x5c, 0xcf, 0xc3, 0x9f,
-	0xe1, 0xc5, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x85, 0x17, 0x64, 0xba, 0x2d, 0x04, 0x00, 0x00,
+	// 1945 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x18, 0x6d, 0x6f, 0xe3, 0x48,
+	0x39, 0xce, 0x5b, 0x93, 0x27, 0x76, 0xe2, 0x4c, 0xd3, 0x55, 0xce, 0xe2, 0xb8, 0xe2, 0xe3, 0xa4,
+	0xb2, 0x27, 0x0d, 0x50, 0x40, 0xe8, 0x4e, 0x77, 0x27, 0x82, 0xe3, 0xbd, 0x0d, 0xd7, 0x26, 0x5d,
+	0x27, 0xa1, 0xbb, 0x7c, 0x20, 0xf2, 0xd6, 0xd3, 0xd6, 0x6a, 0x6c, 0x07, 0xc7, 0xd9, 0xd5, 0xf2,
+	0x81, 0x4f, 0x08, 0x89, 0x9f, 0xc1, 0x8f, 0xe0, 0xff, 0xa1, 0x79, 0xb1, 0x3d, 0x4e, 0x9a, 0x25,
+	0x94, 0x6f, 0xf3, 0x3c, 0xf3, 0xbc, 0xcd, 0xcc, 0xf3, 0x3a, 0xa0, 0xaf, 0xe2, 0x28, 0x89, 0x7e,
+	0x7e, 0xeb, 0x2f, 0x09, 0x66, 0x4b, 0xf3, 0x67, 0xd0, 0x9a, 0xac, 0x48, 0xe8, 0x90, 0xbf, 0x6c,
+	0xc8, 0x3a, 0x41, 0x06, 0x34, 0xe8, 0x66, 0xe8, 0x06, 0xa4, 0xaf, 0x9c, 0x2a, 0x67, 0x4d, 0x27,
+	0x83, 0xcd, 0x6f, 0x40, 0xe5, 0xa4, 0xeb, 0x55, 0x14, 0xae, 0x09, 0x6a, 0x43, 0xd9, 0xf7, 0xfa,
+	0x15, 0x46, 0x55, 0xf6, 0x3d, 0xf4, 0x0c, 0xea, 0x31, 0x59, 0x6f, 0x96, 0x49, 0xbf, 0x7c, 0xaa,
+	0x9c, 0x35, 0x1c, 0x01, 0xfd, 0xa1, 0xda, 0x50, 0xf4, 0xb2, 0xf9, 0x35, 0xa8, 0xd6, 0x32, 0x5a,
+	0x93, 0x54, 0xd3, 0x36, 0x77, 0x0f, 0x6a, 0xee, 0xdb, 0x28, 0x4e, 0x99, 0x39, 0x20, 0x78, 0x3b,
+	0xa0, 0x09, 0x5e, 0xae, 0x9a, 0x5a, 0x3d, 0x4d, 0xdc, 0xe4, 0x10, 0xab, 0xff, 0x59, 0x06, 0x95,
+	0xd3, 0x0a, 0xb3, 0x11, 0x54, 0x93, 0x0f, 0xab, 0x94, 0x90, 0xad, 0x29, 0x6e, 0xed, 0xff, 0x95,
+	0x30, 0xdd, 0x15, 0x87, 0xad, 0xd1, 0xe7, 0xa0, 0x2d, 0xdd, 0x75, 0xb2, 0x08, 0x22, 0xcf, 0xbf,
+	0xf5, 0x09, 0xb7, 0xb5, 0xe2, 0xa8, 0x14, 0x79, 0x29, 0x70, 0x94, 0x31, 0x88, 0x3c, 0xd2, 0xaf,
+	0x9e, 0x2a, 0x67, 0x9a, 0xc3, 0xd6, 0xe8, 0x13, 0x68, 0x04, 0x91, 0xb7, 0x48, 0xfc, 0x80, 0xf4,
+	0x6b, 0x8c, 0xe7, 0x28, 0x88, 0xbc, 0x99, 0x1f, 0x10, 0xf4, 0x19, 0xb4, 0x96, 0x7e, 0xf8, 0xb0,
+	0x48, 0xdc, 0xf8, 0x8e, 0x24, 0xfd, 0x3a, 0x33, 0x01, 0x28, 0x6a, 0xc6, 0x30, 0x48, 0x87, 0xca,
+	0xc6, 0xf7, 0xfa, 0x47, 0x4c, 0x1c, 0x5d, 0x52, 0xcc, 0x9d, 0xef, 0xf5, 0x1b, 0x1c, 0x73, 0xc7,
+	0x31, 0x1e, 0x79, 0xd7, 0x6f, 0x9e, 0x2a, 0x67, 0x55, 0x87, 0x2e, 0x29, 0xc6, 0x0f, 0xa3, 0x3e,
+	0x70, 0x8c, 0x1f, 0x46, 0xf4, 0x36, 0x43, 0x2a, 0xb6, 0xdf, 0x62, 0x38, 0x0e, 0x98, 0x13, 0x68,
+	0x39, 0xc4, 0xf5, 0x8a, 0x4f, 0x50, 0x95, 0x1f, 0x30, 0xba, 0xbd, 0x5d, 0x93, 0x44, 0xdc, 0x83,
+	0x80, 0xb2, 0xdb, 0xa9, 0xe4, 0xb7, 0x23, 0x1e, 0xc6, 0x02, 0xcd, 0x8a, 0x89, 0x9b, 0x90, 0x03,
+	0x5e, 0x82, 0x8a, 0x77, 0x93, 0x28, 0xf0, 0x6f, 0x52, 0xff, 0xe0, 0x90, 0xf9, 0x1d, 0xb4, 0x53,
+	0x21, 0x4f, 0xf2, 0xac, 0x25, 0xa8, 0xd7, 0xb1, 0x9f, 0x6c, 0x79, 0x56, 0xfd, 0x90, 0x63, 0x79,
+	0x6e, 0xe2, 0xb2, 0x0b, 0x50, 0x1d, 0xb6, 0xa6, 0xf6, 0xdf, 0xdc, 0x93, 0x9b, 0x87, 0xf5, 0x26,
+	0x60, 0x6f, 0xa7, 0x3a, 0x19, 0x2c, 0xb4, 0x7d, 0x0e, 0x9a, 0xd0, 0x96, 0xfb, 0x13, 0xbb, 0x1d,
+	0x25, 0xbf, 0x1d, 0xd3, 0x03, 0x95, 0x5f, 0xf4, 0x7e, 0x9a, 0x4c, 0x7d, 0x59, 0x52, 0xaf, 0x43,
+	0x85, 0x44, 0xb7, 0xec, 0xe4, 0x0d, 0x87, 0x2e, 0x0b, 0x06, 0x55, 0x8b, 0x06, 0x99, 0xdf, 0x02,
+	0x7c, 0x4f, 0x92, 0x7d, 0x01, 0xf5, 0x09, 0x34, 0xde, 0x2e, 0xa3, 0x9b, 0x87, 0x85, 0xef, 0x89,
+	0x83, 0x1f, 0x31, 0x78, 0xe4, 0x89, 0x93, 0x5c, 0x41, 0x8b, 0xb1, 0x0b, 0x1b, 0x65, 0x7a, 0xa5,
+	0x40, 0xff, 0x68, 0x78, 0xa4, 0xe6, 0x57, 0x72, 0xf3, 0xcd, 0x0d, 0xb4, 0x2e, 0xfc, 0x75, 0x72,
+	0xa0, 0x33, 0x3c, 0xfa, 0x28, 0x3d, 0xa8, 0x2d, 0xfd, 0xc0, 0x4f, 0x84, 0xb3, 0x71, 0x00, 0xfd,
+	0x08, 0x9a, 0x31, 0xb9, 0xd9, 0xc4, 0x6b, 0xff, 0x1d, 0x8f, 0xb5, 0x86, 0x93, 0x23, 0xcc, 0x01,
+	0xa8, 0x5c, 0xad, 0x38, 0xc9, 0x67, 0x50, 0xa3, 0x7a, 0xd6, 0x7d, 0xe5, 0xb4, 0x72, 0xd6, 0x3a,
+	0x6f, 0xe2, 0x17, 0xfe, 0x92, 0x8c, 0xc2, 0xdb, 0xc8, 0xe1, 0xf8, 0xf4, 0x9a, 0xcb, 0xd9, 0x35,
+	0x9b, 0xff, 0x50, 0xa0, 0x91, 0x52, 0xd1, 0xa3, 0x49, 0x36, 0xb3, 0xf5, 0xbe, 0x2b, 0x60, 0xc1,
+	0x5f, 0x91, 0x82, 0x7f, 0x27, 0x6b, 0x54, 0x1f, 0xc9, 0x1a, 0xfb, 0x33, 0x84, 0xf9, 0x25, 0x68,
+	0x0e, 0x09, 0xa2, 0x77, 0x87, 0x44, 0x94, 0xa9, 0x43, 0x3b, 0x25, 0x16, 0x89, 0x11, 0x83, 0xce,
+	0x31, 0x83, 0xe5, 0xf2, 0x10, 0x09, 0xc7, 0xd0, 0x95, 0xe8, 0x85, 0x10, 0x8b, 0xda, 0x40, 0xb7,
+	0x53, 0x09, 0x7d, 0x38, 0x8a, 0x96, 0x9e, 0x24, 0x20, 0x05, 0xe9, 0x4e, 0x48, 0xde, 0xb3, 0x9d,
+	0x32, 0xdf, 0x11, 0x20, 0xb7, 0x8d, 0x0b, 0x11, 0x62, 0xbf, 0x03, 0xf5, 0xf2, 0xc1, 0xf3, 0xe3,
+	0x43, 0xdc, 0x03, 0x41, 0x75, 0x45, 0xe2, 0x80, 0x09, 0xd5, 0x1c, 0xb6, 0xa6, 0x55, 0x40, 0xf0,
+	0x0b, 0x81, 0x03, 0xe8, 0x30, 0xc4, 0x61, 0x67, 0x7d, 0x54, 0x26, 0x02, 0x3d, 0x17, 0x21, 0xc4,
+	0x76, 0xa1, 0xf3, 0x82, 0xb8, 0xc9, 0x26, 0x26, 0x6b, 0x21, 0x96, 0x5e, 0x6b, 0x8e, 0x12, 0x5e,
+	0x46, 0x55, 0x09, 0x1c, 0x73, 0xb4, 0xa6, 0x93, 0xc1, 0xe6, 0xdf, 0xe8, 0xb5, 0xba, 0xde, 0x34,
+	0x89, 0x89, 0x1b, 0xfc, 0x3f, 0xe1, 0xf0, 0x0c, 0xea, 0x4b, 0x12, 0xde, 0x25, 0xf7, 0x22, 0x1e,
+	0x04, 0x84, 0x3e, 0x05, 0xb8, 0xb9, 0xdf, 0x84, 0x0f, 0x0b, 0xe6, 0x94, 0xdc, 0xc7, 0x9a, 0x0c,
+	0x33, 0xa5, 0xf9, 0xc7, 0x01, 0x24, 0xeb, 0x17, 0x16, 0xe7, 0x4a, 0x94, 0x47, 0x13, 0xe1, 0x47,
+	0x33, 0x91, 0x79, 0x0d, 0x5d, 0x96, 0xf8, 0xb8, 0xd0, 0x97, 0xc4, 0xf5, 0x48, 0xfc, 0xdf, 0xee,
+	0xfb, 0x90, 0x90, 0x31, 0x6f, 0x01, 0x49, 0x82, 0xd3, 0xdb, 0x7a, 0x0e, 0xf5, 0x7b, 0xa6, 0x83,
+	0xc9, 0x6d, 0x9d, 0x23, 0xbc, 0xa3, 0xdd, 0x11, 0x14, 0x07, 0x1e, 0xc0, 0x86, 0xe3, 0x82, 0x9e,
+	0x8f, 0xe4, 0x66, 0x39, 0xeb, 0x72, 0xbf, 0xce, 0x60, 0xd3, 0x83, 0x8e, 0x25, 0xd6, 0x87, 0xbc,
+	0xec, 0xa7, 0x00, 0x3c, 0xad, 0x4a, 0x77, 0xd1, 0x64, 0x18, 0xfa, 0x52, 0xf4, 0x4d, 0x18, 0xb0,
+	0x16, 0x96, 0x0a, 0xc8, 0xfc, 0x13, 0xe8, 0xb9, 0x96, 0xa7, 0x59, 0x5a, 0x90, 0x5d, 0x39, 0xd3,
+	0x32, 0xd9, 0x7f, 0x57, 0x00, 0xcd, 0x57, 0xcb, 0xc8, 0xf5, 0x7e, 0x4f, 0xee, 0xfc, 0xf0, 0xc0,
+	0xd8, 0x39, 0x28, 0xfd, 0x3d, 0x83, 0xfa, 0xca, 0x8d, 0x49, 0x98, 0x88, 0xb6, 0x42, 0x40, 0x2c,
+	0xf6, 0xdc, 0x38, 0x61, 0xd9, 0xae, 0xe6, 0xb0, 0xb5, 0xf9, 0x05, 0x1c, 0x17, 0xac, 0x28, 0x14,
+	0x7f, 0x25, 0xad, 0x63, 0x66, 0x90, 0x92, 0x0d, 0x56, 0x2b, 0x12, 0x6e, 0x35, 0x2f, 0xca, 0xc1,
+	0x55, 0xbe, 0xb2, 0xa7, 0xca, 0x6f, 0x17, 0xd5, 0xe7, 0xd0, 0x2b, 0xaa, 0xfb, 0x48, 0x99, 0xff,
+	0x2d, 0xd4, 0x1c, 0x37, 0xbc, 0xdb, 0x1f, 0x59, 0x79, 0xf8, 0x96, 0xe5, 0xf0, 0xcd, 0x8f, 0x4e,
+	0x3b, 0xd3, 0xcd, 0x7a, 0xcf, 0x99, 0xcc, 0x5b, 0xe8, 0x15, 0xc9, 0xa4, 0xd4, 0xf3, 0xbf, 0xbc,
+	0xd4, 0x8f, 0xa1, 0x1e, 0x53, 0x3b, 0xb9, 0x23, 0xb4, 0xce, 0xeb, 0x98, 0x99, 0xed, 0x08, 0xac,
+	0x79, 0x0e, 0xc0, 0xf5, 0x5c, 0xb9, 0x71, 0xfe, 0x56, 0x4a, 0xfe, 0x56, 0x34, 0x9a, 0x02, 0xef,
+	0x37, 0xc2, 0xc3, 0xe8, 0xd2, 0xf4, 0xd2, 0x23, 0x58, 0x51, 0x10, 0xf8, 0xc9, 0xbe, 0x67, 0xf9,
+	0x98, 0x7f, 0xfe, 0x04, 0x6a, 0x54, 0x78, 0x6a, 0x55, 0x0b, 0xe7, 0x46, 0x38, 0x7c, 0xc7, 0x7c,
+	0x0d, 0xbd, 0xa2, 0x96, 0x27, 0x86, 0x82, 0xb0, 0xbf, 0x92, 0xdb, 0xff, 0xd3, 0x34, 0x06, 0x06,
+	0x74, 0xd0, 0xd8, 0xf7, 0x02, 0x27, 0x70, 0x5c, 0xa0, 0x12, 0x25, 0xe2, 0x1a, 0x3a, 0x74, 0x14,
+	0xa2, 0x1d, 0xc3, 0x21, 0xd1, 0xd3, 0x83, 0xda, 0xed, 0xd2, 0xbd, 0x5b, 0x8b, 0xd2, 0xc3, 0x81,
+	0xac, 0x1e, 0x55, 0xa4, 0x7a, 0x64, 0x82, 0x9e, 0x0b, 0xde, 0x13, 0x10, 0xaf, 0xa0, 0x33, 0x8b,
+	0x37, 0xe1, 0x8d, 0xbb, 0xdd, 0xf2, 0x16, 0x6e, 0x3d, 0x33, 0xa6, 0xbc, 0xc7, 0x41, 0xa4, 0x6e,
+	0x9e, 0x96, 0xc1, 0x5c, 0xa4, 0x38, 0xe3, 0x57, 0xd0, 0x9a, 0x7e, 0x08, 0x6f, 0x9e, 0xa0, 0xc2,
+	0x6c, 0x83, 0xca, 0x59, 0x85, 0xa8, 0x31, 0xa8, 0xd6, 0x7d, 0x10, 0x79, 0x4f, 0x34, 0x77, 0xa7,
+	0x62, 0xd0, 0x79, 0x90, 0xcb, 0x13, 0x0a, 0xfe, 0x4c, 0x15, 0x44, 0xef, 0xc3, 0xa7, 0x28, 0x10,
+	0x23, 0x57, 0x85, 0x79, 0xbb, 0x3c, 0x72, 0x55, 0x39, 0xe6, 0xce, 0xf7, 0xb8, 0x42, 0x26, 0x5f,
+	0x28, 0xbc, 0x87, 0xb6, 0x75, 0x4f, 0xfb, 0xb7, 0xf5, 0x53, 0x54, 0xd2, 0x59, 0x97, 0x32, 0xa7,
+	0x4d, 0x2e, 0x03, 0x28, 0x36, 0x60, 0x58, 0x5e, 0xce, 0x39, 0x40, 0xbb, 0x91, 0x4c, 0x13, 0x57,
+	0xfe, 0x7c, 0x0d, 0x0d, 0xe6, 0x24, 0x4b, 0xf7, 0x0e, 0xa9, 0xd0, 0x98, 0x2c, 0x9c, 0xe1, 0x64,
+	0x7c, 0xf1, 0x46, 0x2f, 0x71, 0xe8, 0xda, 0x61, 0x90, 0x82, 0x00, 0xea, 0x74, 0xef, 0xda, 0xd1,
+	0xcb, 0x7c, 0x67, 0x70, 0x75, 0x65, 0x8f, 0x87, 0x7a, 0x95, 0x43, 0x96, 0x63, 0x0f, 0x66, 0xb6,
+	0xde, 0xe0, 0x74, 0xf6, 0x6b, 0xeb, 0x42, 0xd7, 0xf9, 0x7a, 0xfa, 0x66, 0x6c, 0xe9, 0xa7, 0xa8,
+	0x05, 0x47, 0x93, 0xc5, 0xcc, 0x99, 0x8f, 0x2d, 0xfd, 0x77, 0xcf, 0xff, 0x55, 0x86, 0xa6, 0x1d,
+	0xc7, 0x51, 0x6c, 0xd1, 0x3c, 0xdf, 0x82, 0xa3, 0xf9, 0xf8, 0x87, 0xf1, 0xe4, 0x7a, 0xcc, 0xb5,
+	0x8e, 0xc6, 0x33, 0xdb, 0x19, 0x0f, 0x2e, 0x74, 0x05, 0xf5, 0x40, 0x1f, 0x8d, 0xff, 0x38, 0xb8,
+	0x18, 0x0d, 0x17, 0x03, 0xe7, 0xfb, 0xf9, 0xa5, 0x3d, 0x9e, 0xe9, 0x65, 0xa4, 0x41, 0x73, 0x3c,
+	0x99, 0x2d, 0x5e, 0x4c, 0xe6, 0xe3, 0xa1, 0x5e, 0xa1, 0x6a, 0xec, 0xd7, 0xa3, 0xe9, 0x6c, 0xaa,
+	0x57, 0xd1, 0x09, 0x74, 0xaf, 0x6c, 0xe7, 0x72, 0x34, 0x9d, 0x8e, 0x26, 0xe3, 0xc5, 0xd0, 0x1e,
+	0x8f, 0xec, 0xa1, 0x5e, 0x43, 0x3a, 0xa8, 0xa3, 0xe9, 0x62, 0x38, 0x72, 0x6c, 0x6b, 0x36, 0x71,
+	0xde, 0xe8, 0x75, 0xd4, 0x05, 0x8d, 0xca, 0xc8, 0x51, 0x47, 0xa9, 0x58, 0xfb, 0xf2, 0x6a, 0xf6,
+	0x46, 0x6f, 0x50, 0x51, 0x53, 0x9b, 0xcb, 0xc9, 0xb5, 0x35, 0xd1, 0x31, 0x74, 0x52, 0xb4, 0xfd,
+	0xfa, 0x6a, 0xe4, 0xd8, 0x43, 0x1d, 0x10, 0x82, 0xf6, 0xab, 0xf9, 0x64, 0x36, 0xa0, 0x27, 0xb7,
+	0xed, 0xa1, 0x3d, 0xd4, 0x5b, 0x94, 0xdf, 0x7a, 0x69, 0x5b, 0x3f, 0x4c, 0xe7, 0x97, 0x8b, 0xcb,
+	0xd1, 0xf4, 0x72, 0x30, 0xb3, 0x5e, 0xea, 0x6a, 0xaa, 0x78, 0x3a, 0xbf, 0xba, 0x9a, 0x38, 0x33,
+	0x7b, 0xa8, 0x6b, 0x54, 0xe4, 0x7c, 0x3c, 0x98, 0xcf, 0x5e, 0xda, 0xe3, 0xd9, 0xc8, 0x1a, 0x50,
+	0x64, 0xfb, 0xfc, 0xdf, 0x00, 0x55, 0x1a, 0xba, 0xe8, 0x97, 0xd0, 0x48, 0xfb, 0x45, 0xa4, 0xe3,
+	0xad, 0x6e, 0xd2, 0xe8, 0xe2, 0xed, 0x66, 0xd2, 0x2c, 0xa1, 0x2f, 0xa0, 0x4a, 0x1f, 0x15, 0xa9,
+	0x58, 0xfa, 0x8f, 0x31, 0x34, 0x2c, 0x7f, 0xb9, 0x98, 0x25, 0x2a, 0x39, 0x4d, 0x10, 0x48, 0xc7,
+	0x5b, 0x49, 0xc8, 0xe8, 0xe2, 0xed, 0xec, 0xc1, 0x25, 0xd3, 0xfa, 0x81, 0x54, 0x2c, 0xfd, 0x99,
+	0x18, 0x1a, 0x96, 0x7f, 0x45, 0x38, 0x19, 0x9d, 0xa2, 0x90, 0x8a, 0xa5, 0x19, 0xce, 0xd0, 0xb0,
+	0x3c, 0x5a, 0x71, 0x03, 0xac, 0x2c, 0xab, 0xe2, 0xad, 0x4e, 0xc8, 0xe8, 0xe2, 0xed, 0xae, 0x85,
+	0x4b, 0x76, 0x88, 0xeb, 0x21, 0x15, 0x4b, 0xbf, 0x0f, 0x86, 0x86, 0xe5, 0x11, 0xd9, 0x2c, 0xa1,
+	0xaf, 0x00, 0xf2, 0xa6, 0x15, 0x21, 0xbc, 0xd3, 0x41, 0x1b, 0xc7, 0x78, 0xb7, 0xab, 0x35, 0x4b,
+	0xbf, 0x50, 0xd0, 0x19, 0xd4, 0xd8, 0x07, 0x11, 0xd2, 0xb0, 0xfc, 0xc9, 0x64, 0xb4, 0x71, 0xf1,
+	0xdf, 0xa8, 0x84, 0xbe, 0x84, 0x3a, 0xff, 0x6c, 0x40, 0x6d, 0x5c, 0xf8, 0xba, 0x30, 0x3a, 0xb8,
+	0xf8, 0x0b, 0x61, 0x96, 0xa8, 0x58, 0xd6, 0x31, 0x22, 0x0d, 0xcb, 0x3f, 0x0c, 0x46, 0x1b, 0x17,
+	0xbe, 0x00, 0xcc, 0x12, 0xfa, 0x06, 0x5a, 0x52, 0x6f, 0x89, 0x8e, 0xf1, 0x6e, 0x47, 0x6b, 0xf4,
+	0xf0, 0x23, 0xed, 0xa7, 0x59, 0x3a, 0x53, 0xd0, 0xd7, 0xd0, 0x92, 0x3a, 0x21, 0x74, 0x8c, 0x77,
+	0xbb, 0x33, 0xa3, 0x87, 0x1f, 0x69, 0x96, 0xcc, 0x12, 0xfa, 0x16, 0x54, 0xb9, 0x5f, 0x41, 0x3d,
+	0x2c, 0x83, 0x29, 0xf7, 0x09, 0x7e, 0xac, 0xa9, 0x91, 0xd9, 0x79, 0x8b, 0x91, 0xb1, 0x17, 0x1a,
+	0x13, 0xe3, 0x64, 0x0b, 0xbb, 0xcb, 0xce, 0xeb, 0x73, 0xc6, 0x5e, 0x68, 0x0a, 0x8c, 0x93, 0x2d,
+	0x6c, 0xc6, 0x9e, 0x1d, 0x9c, 0x95, 0xd7, 0xec, 0xe0, 0x72, 0x49, 0x36, 0x7a, 0x45, 0xa4, 0xfc,
+	0x92, 0x7c, 0x74, 0x45, 0x6d, 0x5c, 0x18, 0x99, 0x8d, 0x0e, 0xde, 0x9a, 0x8a, 0x4b, 0xe8, 0xd7,
+	0xd0, 0xcc, 0xe6, 0x5c, 0xd4, 0xc5, 0xdb, 0x33, 0xb2, 0x81, 0xf0, 0xee, 0x18, 0x2c, 0x54, 0xb0,
+	0x8c, 0xdd, 0xc6, 0x7c, 0x21, 0xab, 0x28, 0x0c, 0xb7, 0xcc, 0x59, 0xd8, 0x28, 0x89, 0x34, 0x2c,
+	0x8f, 0xb9, 0x46, 0x1b, 0x17, 0xa7, 0x56, 0x16, 0x42, 0xe9, 0xd0, 0x89, 0x74, 0xbc, 0x35, 0xc2,
+	0x1a, 0x5d, 0xbc, 0x33, 0x91, 0x32, 0x96, 0xb4, 0x40, 0x23, 0x1d, 0x6f, 0x95, 0x7f, 0xa3, 0x8b,
+	0x77, 0xaa, 0x37, 0x0f, 0xfb, 0x0f, 0xe1, 0x0d, 0x0d, 0xfb, 0xbc, 0x8c, 0x1b, 0x9a, 0x80, 0x64,
+	0xb3, 0x59, 0x2d, 0xa5, 0xa1, 0x23, 0xd5, 0x68, 0xa3, 0x9d, 0x82, 0x45, 0xca, 0xe8, 0x7d, 0xc8,
+	0x28, 0xf3, 0x62, 0x6b, 0xb4, 0x53, 0x30, 0xa3, 0xc4, 0x70, 0x24, 0x6a, 0x16, 0xea, 0xe0, 0x62,
+	0x9d, 0x34, 0x74, 0xbc, 0x55, 0xce, 0xcc, 0xd2, 0xdb, 0x3a, 0xfb, 0x8b, 0xfe, 0xd5, 0x7f, 0x06,
+	0x00, 0x7a, 0xca, 0x3c, 0xb0, 0x9f, 0x16, 0x00, 0x00,
 }
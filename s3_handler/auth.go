@@ -0,0 +1,250 @@
+package s3_handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signV4Algorithm  = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	streamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	amzDateFormat    = "20060102T150405Z"
+	scopeDateFormat  = "20060102"
+	// maxSkew is the largest accepted difference between the request date
+	// and the server clock
+	maxSkew = 15 * time.Minute
+	// maxExpires is the longest validity of a presigned url, in seconds
+	maxExpires = 7 * 24 * 60 * 60
+)
+
+// signature holds the SigV4 fields of a request, from its Authorization
+// header or from its query when the url is presigned
+type signature struct {
+	accessKey     string
+	date          time.Time
+	scope         string
+	signedHeaders []string
+	signature     string
+	payload       string
+	expires       int64
+	presigned     bool
+}
+
+// authenticate verifies the SigV4 signature of r against the configured
+// credentials, it returns the SHA-256 the payload must match, or "" when
+// the payload is not signed
+func (h *s3Handler) authenticate(r *http.Request) (string, *s3Error) {
+	var (
+		s   *signature
+		err *s3Error
+	)
+	if r.URL.Query().Get("X-Amz-Algorithm") != "" {
+		s, err = parseQuery(r.URL.Query())
+	} else {
+		s, err = parseHeader(r.Header)
+	}
+	if err != nil {
+		return "", err
+	}
+	secret, ok := h.opts.credentials[s.accessKey]
+	if !ok {
+		return "", errInvalidAccessKeyId
+	}
+	now := time.Now()
+	if s.presigned {
+		if now.Before(s.date.Add(-maxSkew)) {
+			return "", errRequestTimeTooSkewed
+		}
+		if now.After(s.date.Add(time.Duration(s.expires) * time.Second)) {
+			return "", errExpiredRequest
+		}
+	} else if d := now.Sub(s.date); d > maxSkew || d < -maxSkew {
+		return "", errRequestTimeTooSkewed
+	}
+	if s.payload == streamingPayload {
+		return "", errNotImplemented
+	}
+
+	sum := sha256.Sum256([]byte(canonicalRequest(r, s)))
+	stringToSign := strings.Join([]string{signV4Algorithm, s.date.Format(amzDateFormat), s.scope, hex.EncodeToString(sum[:])}, "\n")
+	key := []byte("AWS4" + secret)
+	for _, v := range strings.Split(s.scope, "/") {
+		key = sumHMAC(key, []byte(v))
+	}
+	expected := hex.EncodeToString(sumHMAC(key, []byte(stringToSign)))
+	if !hmac.Equal([]byte(expected), []byte(s.signature)) {
+		return "", errSignatureDoesNotMatch
+	}
+	if s.payload == unsignedPayload {
+		return "", nil
+	}
+	return s.payload, nil
+}
+
+func parseHeader(header http.Header) (*signature, *s3Error) {
+	auth := header.Get("Authorization")
+	if auth == "" {
+		return nil, errAccessDenied
+	}
+	if !strings.HasPrefix(auth, signV4Algorithm+" ") {
+		return nil, errAuthorizationHeader
+	}
+	fields := make(map[string]string)
+	for _, v := range strings.Split(strings.TrimPrefix(auth, signV4Algorithm), ",") {
+		kv := strings.SplitN(strings.TrimSpace(v), "=", 2)
+		if len(kv) != 2 {
+			return nil, errAuthorizationHeader
+		}
+		fields[kv[0]] = kv[1]
+	}
+	s := &signature{
+		signature: fields["Signature"],
+		payload:   header.Get("X-Amz-Content-Sha256"),
+	}
+	if s.payload == "" {
+		s.payload = unsignedPayload
+	}
+	date := header.Get("X-Amz-Date")
+	if date == "" {
+		date = header.Get("Date")
+	}
+	if !s.parse(fields["Credential"], date, fields["SignedHeaders"]) {
+		return nil, errAuthorizationHeader
+	}
+	return s, nil
+}
+
+func parseQuery(q url.Values) (*signature, *s3Error) {
+	if q.Get("X-Amz-Algorithm") != signV4Algorithm {
+		return nil, errAuthorizationQuery
+	}
+	s := &signature{
+		signature: q.Get("X-Amz-Signature"),
+		payload:   q.Get("X-Amz-Content-Sha256"),
+		presigned: true,
+	}
+	if s.payload == "" {
+		s.payload = unsignedPayload
+	}
+	var err error
+	s.expires, err = strconv.ParseInt(q.Get("X-Amz-Expires"), 10, 64)
+	if err != nil || s.expires < 0 || s.expires > maxExpires {
+		return nil, errAuthorizationQuery
+	}
+	if !s.parse(q.Get("X-Amz-Credential"), q.Get("X-Amz-Date"), q.Get("X-Amz-SignedHeaders")) {
+		return nil, errAuthorizationQuery
+	}
+	return s, nil
+}
+
+// parse sets the fields common to the header and the query signatures
+func (s *signature) parse(credential, date, signedHeaders string) bool {
+	t, err := time.Parse(amzDateFormat, date)
+	if err != nil {
+		if t, err = http.ParseTime(date); err != nil {
+			return false
+		}
+	}
+	s.date = t.UTC()
+	// the credential is <key>/<date>/<region>/s3/aws4_request
+	parts := strings.SplitN(credential, "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	s.accessKey, s.scope = parts[0], parts[1]
+	scope := strings.Split(s.scope, "/")
+	if len(scope) != 4 || scope[0] != s.date.Format(scopeDateFormat) || scope[2] != "s3" || scope[3] != "aws4_request" {
+		return false
+	}
+	s.signedHeaders = strings.Split(signedHeaders, ";")
+	// the host is always signed so that a signature is only valid for it
+	for _, k := range s.signedHeaders {
+		if k == "host" {
+			return s.signature != ""
+		}
+	}
+	return false
+}
+
+// canonicalRequest returns the canonical form of r which is signed
+func canonicalRequest(r *http.Request, s *signature) string {
+	q := r.URL.Query()
+	q.Del("X-Amz-Signature")
+	query := strings.Replace(q.Encode(), "+", "%20", -1)
+
+	headers := make([]string, len(s.signedHeaders))
+	for i, k := range s.signedHeaders {
+		values := r.Header[http.CanonicalHeaderKey(k)]
+		if k == "host" {
+			values = []string{r.Host}
+		}
+		v := make([]string, len(values))
+		for j := range values {
+			v[j] = strings.Join(strings.Fields(values[j]), " ")
+		}
+		headers[i] = k + ":" + strings.Join(v, ",")
+	}
+	sort.Strings(headers)
+
+	return strings.Join([]string{
+		r.Method,
+		encodePath(r.URL.Path),
+		query,
+		strings.Join(headers, "\n") + "\n",
+		strings.Join(s.signedHeaders, ";"),
+		s.payload,
+	}, "\n")
+}
+
+// encodePath escapes path like the S3 clients do before signing it
+func encodePath(path string) string {
+	var b strings.Builder
+	for _, c := range []byte(path) {
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+	return b.String()
+}
+
+func sumHMAC(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// payloadReader fails with errContentSHA256Mismatch at the end of a body
+// which does not match the signed SHA-256
+type payloadReader struct {
+	r        io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func (p *payloadReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.hash.Write(b[:n])
+	if err == io.EOF && hex.EncodeToString(p.hash.Sum(nil)) != p.expected {
+		return n, errContentSHA256Mismatch
+	}
+	return n, err
+}
+
+func (p *payloadReader) Close() error {
+	if c, ok := p.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
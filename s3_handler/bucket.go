@@ -0,0 +1,264 @@
+package s3_handler
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/partitio/go-file/client"
+)
+
+const (
+	// timeFormat is the format of the dates in the xml documents
+	timeFormat = "2006-01-02T15:04:05.000Z"
+	// maxKeys is the largest number of keys returned by a listing
+	maxKeys = 1000
+	// listPage is the number of files listed by each List call
+	listPage = 1000
+)
+
+type owner struct {
+	ID          string
+	DisplayName string
+}
+
+type bucketInfo struct {
+	Name         string
+	CreationDate string
+}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name     `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   owner        `xml:"Owner"`
+	Buckets []bucketInfo `xml:"Buckets>Bucket"`
+}
+
+type object struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+type commonPrefix struct {
+	Prefix string
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string
+	Prefix                string
+	Delimiter             string `xml:",omitempty"`
+	StartAfter            string `xml:",omitempty"`
+	ContinuationToken     string `xml:",omitempty"`
+	NextContinuationToken string `xml:",omitempty"`
+	KeyCount              int
+	MaxKeys               int
+	IsTruncated           bool
+	Contents              []object
+	CommonPrefixes        []commonPrefix
+}
+
+// list returns all the files of dirname
+func list(c client.FileClient, dirname string, recursive bool) ([]os.FileInfo, error) {
+	var infos []os.FileInfo
+	for {
+		page, eof, err := c.List(dirname, int64(len(infos)), listPage, recursive)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, page...)
+		if eof || len(page) == 0 {
+			return infos, nil
+		}
+	}
+}
+
+// etag returns the ETag of a file, it changes with its size and its
+// modification time. It is not an MD5, so that clients do not compare it
+// with the MD5 of the content.
+func etag(fi os.FileInfo) string {
+	return `"` + strconv.FormatInt(fi.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(fi.Size(), 16) + `"`
+}
+
+func (h *s3Handler) listBuckets(c client.FileClient, w http.ResponseWriter, r *http.Request) {
+	infos, err := list(c, "", false)
+	if err != nil {
+		writeError(w, r, fileError(err, errNoSuchBucket))
+		return
+	}
+	res := &listAllMyBucketsResult{Owner: owner{ID: "go-file", DisplayName: "go-file"}}
+	for _, fi := range infos {
+		if fi.IsDir() && validBucket(fi.Name()) {
+			res.Buckets = append(res.Buckets, bucketInfo{Name: fi.Name(), CreationDate: fi.ModTime().UTC().Format(timeFormat)})
+		}
+	}
+	writeXML(w, http.StatusOK, res)
+}
+
+// statBucket returns an error unless bucket is a directory
+func statBucket(c client.FileClient, bucket string) *s3Error {
+	s, err := c.Stat(bucket)
+	if err != nil {
+		return fileError(err, errNoSuchBucket)
+	}
+	if s.Type != "Directory" {
+		return errNoSuchBucket
+	}
+	return nil
+}
+
+func (h *s3Handler) headBucket(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket string) {
+	if err := statBucket(c, bucket); err != nil {
+		w.WriteHeader(err.status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *s3Handler) createBucket(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket string) {
+	if err := c.Mkdir(bucket, 0755); err != nil {
+		if os.IsExist(err) {
+			writeError(w, r, errBucketAlreadyOwned)
+			return
+		}
+		writeError(w, r, fileError(err, errNoSuchBucket))
+		return
+	}
+	w.Header().Set("Location", "/"+bucket)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *s3Handler) deleteBucket(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket string) {
+	if err := statBucket(c, bucket); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err := c.Remove(bucket); err != nil {
+		writeError(w, r, fileError(err, errNoSuchBucket))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listObjects implements ListObjectsV2. Only the directory of the prefix is
+// listed, and only its direct children when the delimiter is a slash.
+func (h *s3Handler) listObjects(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket string) {
+	q := r.URL.Query()
+	prefix, delimiter := q.Get("prefix"), q.Get("delimiter")
+	max := maxKeys
+	if v := q.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, r, errInvalidArgument)
+			return
+		}
+		if n < max {
+			max = n
+		}
+	}
+	res := &listBucketResult{
+		Name:              bucket,
+		Prefix:            prefix,
+		Delimiter:         delimiter,
+		StartAfter:        q.Get("start-after"),
+		ContinuationToken: q.Get("continuation-token"),
+		MaxKeys:           max,
+	}
+	marker := res.StartAfter
+	if res.ContinuationToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(res.ContinuationToken)
+		if err != nil {
+			writeError(w, r, errInvalidArgument)
+			return
+		}
+		marker = string(b)
+	}
+	if err := statBucket(c, bucket); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	dir := ""
+	if i := strings.LastIndexByte(prefix, '/'); i >= 0 {
+		dir = prefix[:i]
+	}
+	var infos []os.FileInfo
+	if _, ok := objectName(bucket, dir); ok || dir == "" {
+		var err error
+		infos, err = list(c, strings.TrimSuffix(bucket+"/"+dir, "/"), delimiter != "/")
+		// a prefix which is not a directory matches nothing
+		if err != nil && !os.IsNotExist(err) && !errors.Is(err, syscall.ENOTDIR) {
+			writeError(w, r, fileError(err, errNoSuchBucket))
+			return
+		}
+	}
+
+	type entry struct {
+		key    string
+		info   os.FileInfo
+		prefix bool
+	}
+	var entries []entry
+	seen := make(map[string]bool)
+	for _, fi := range infos {
+		key := fi.Name()
+		if dir != "" {
+			key = dir + "/" + key
+		}
+		if fi.IsDir() {
+			key += "/"
+		}
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				p := key[:len(prefix)+i+len(delimiter)]
+				if !seen[p] {
+					seen[p] = true
+					entries = append(entries, entry{key: p, prefix: true})
+				}
+				continue
+			}
+		}
+		if !fi.IsDir() {
+			entries = append(entries, entry{key: key, info: fi})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	for _, e := range entries {
+		if e.key <= marker {
+			continue
+		}
+		if res.KeyCount == max {
+			res.IsTruncated = true
+			break
+		}
+		if e.prefix {
+			res.CommonPrefixes = append(res.CommonPrefixes, commonPrefix{Prefix: e.key})
+		} else {
+			res.Contents = append(res.Contents, object{
+				Key:          e.key,
+				LastModified: e.info.ModTime().UTC().Format(timeFormat),
+				ETag:         etag(e.info),
+				Size:         e.info.Size(),
+				StorageClass: "STANDARD",
+			})
+		}
+		res.KeyCount++
+		marker = e.key
+	}
+	if res.IsTruncated {
+		res.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(marker))
+	}
+	writeXML(w, http.StatusOK, res)
+}
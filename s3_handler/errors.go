@@ -0,0 +1,93 @@
+package s3_handler
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"os"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+// s3Error is an error of the S3 API, written as an xml Error document
+type s3Error struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *s3Error) Error() string {
+	return e.code + ": " + e.message
+}
+
+var (
+	errAccessDenied          = &s3Error{http.StatusForbidden, "AccessDenied", "Access Denied."}
+	errInvalidAccessKeyId    = &s3Error{http.StatusForbidden, "InvalidAccessKeyId", "The access key Id you provided does not exist in our records."}
+	errSignatureDoesNotMatch = &s3Error{http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided."}
+	errRequestTimeTooSkewed  = &s3Error{http.StatusForbidden, "RequestTimeTooSkewed", "The difference between the request time and the server's time is too large."}
+	errExpiredRequest        = &s3Error{http.StatusForbidden, "AccessDenied", "Request has expired."}
+	errAuthorizationHeader   = &s3Error{http.StatusBadRequest, "AuthorizationHeaderMalformed", "The authorization header is malformed."}
+	errAuthorizationQuery    = &s3Error{http.StatusBadRequest, "AuthorizationQueryParametersError", "The authorization query parameters are malformed."}
+	errContentSHA256Mismatch = &s3Error{http.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed."}
+	errBadDigest             = &s3Error{http.StatusBadRequest, "BadDigest", "The Content-MD5 you specified did not match what we received."}
+	errInvalidDigest         = &s3Error{http.StatusBadRequest, "InvalidDigest", "The Content-MD5 you specified is not valid."}
+	errInvalidBucketName     = &s3Error{http.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid."}
+	errInvalidKey            = &s3Error{http.StatusBadRequest, "InvalidArgument", "The specified key cannot be mapped to a file."}
+	errInvalidArgument       = &s3Error{http.StatusBadRequest, "InvalidArgument", "Invalid argument."}
+	errIncompleteBody        = &s3Error{http.StatusBadRequest, "IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header."}
+	errInvalidPart           = &s3Error{http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found."}
+	errInvalidPartOrder      = &s3Error{http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order."}
+	errMalformedXML          = &s3Error{http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema."}
+	errNoSuchBucket          = &s3Error{http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist."}
+	errNoSuchKey             = &s3Error{http.StatusNotFound, "NoSuchKey", "The specified key does not exist."}
+	errNoSuchUpload          = &s3Error{http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist."}
+	errMethodNotAllowed      = &s3Error{http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource."}
+	errBucketAlreadyOwned    = &s3Error{http.StatusConflict, "BucketAlreadyOwnedByYou", "The bucket you tried to create already exists, and you own it."}
+	errBucketNotEmpty        = &s3Error{http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty."}
+	errMissingContentLength  = &s3Error{http.StatusLengthRequired, "MissingContentLength", "You must provide the Content-Length HTTP header."}
+	errNotImplemented        = &s3Error{http.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented."}
+)
+
+// fileError converts an error of the File service, notFound is returned
+// when the file does not exist, an InternalError when it is nil
+func fileError(err error, notFound *s3Error) *s3Error {
+	var e *s3Error
+	switch {
+	case errors.As(err, &e):
+		return e
+	case os.IsNotExist(err) && notFound != nil:
+		return notFound
+	case os.IsPermission(err):
+		return errAccessDenied
+	case errors.Is(err, syscall.ENOTEMPTY):
+		return errBucketNotEmpty
+	case errors.Is(err, syscall.ENOTDIR), errors.Is(err, syscall.EISDIR):
+		return errInvalidKey
+	}
+	return &s3Error{http.StatusInternalServerError, "InternalError", err.Error()}
+}
+
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string
+	Message  string
+	Resource string
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err *s3Error) {
+	logrus.Tracef("s3 %s %s: %v", r.Method, r.URL.Path, err)
+	writeXML(w, err.status, &errorResponse{Code: err.code, Message: err.message, Resource: r.URL.Path})
+}
+
+func writeXML(w http.ResponseWriter, status int, v interface{}) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	w.Write(b)
+}
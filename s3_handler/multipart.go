@@ -0,0 +1,220 @@
+package s3_handler
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/partitio/go-file/client"
+	proto "github.com/partitio/go-file/proto"
+)
+
+// maxParts is the largest part number of a multipart upload
+const maxParts = 10000
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string
+	Key      string
+	UploadId string
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []struct {
+		PartNumber int
+		ETag       string
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string
+	Bucket   string
+	Key      string
+	ETag     string
+}
+
+// loadUpload checks that the upload id exists and is an upload of name
+func loadUpload(c client.FileClient, id, name string) *s3Error {
+	if b, err := hex.DecodeString(id); err != nil || len(b) != 16 {
+		return errNoSuchUpload
+	}
+	rsp, err := c.UploadStatus(id)
+	if err != nil {
+		return uploadError(err, errNoSuchUpload)
+	}
+	if rsp.Filename != name {
+		return errNoSuchUpload
+	}
+	return nil
+}
+
+// uploadError converts an error of the upload sessions of the File service,
+// the sessions which do not exist are reported as NoSuchUpload
+func uploadError(err error, notFound *s3Error) *s3Error {
+	if errors.Is(err, client.ErrSessionNotFound) || errors.Is(err, client.ErrSessionExpired) {
+		return errNoSuchUpload
+	}
+	return fileError(err, notFound)
+}
+
+// createMultipartUpload begins an empty upload session of the File service,
+// which receives the parts
+func (h *s3Handler) createMultipartUpload(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket, key, name string) {
+	if err := statBucket(c, bucket); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	id, err := c.UploadBegin(name, 0, 0)
+	if err != nil {
+		writeError(w, r, fileError(err, errNoSuchKey))
+		return
+	}
+	writeXML(w, http.StatusOK, &initiateMultipartUploadResult{Bucket: bucket, Key: key, UploadId: id})
+}
+
+// writePart uploads size bytes read from body as the part of the multipart
+// upload id, the part is only sealed once body is read without errors and
+// matches digest when it is not nil. It returns the MD5 of the part.
+func writePart(c client.FileClient, id string, part int, body io.Reader, size int64, digest []byte) (string, *s3Error) {
+	partId, err := c.UploadBeginPart(id, part, size)
+	if err != nil {
+		return "", uploadError(err, errNoSuchUpload)
+	}
+	hash, sum := sha256.New(), md5.New()
+	body = io.TeeReader(body, io.MultiWriter(hash, sum))
+	b := make([]byte, client.BlockSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(body, b)
+		if n > 0 {
+			if err := c.UploadAppend(partId, offset, b[:n]); err != nil {
+				c.UploadAbort(partId)
+				return "", uploadError(err, errNoSuchUpload)
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			c.UploadAbort(partId)
+			return "", fileError(err, errNoSuchUpload)
+		}
+	}
+	if offset != size {
+		c.UploadAbort(partId)
+		return "", errIncompleteBody
+	}
+	if digest != nil && !bytes.Equal(digest, sum.Sum(nil)) {
+		c.UploadAbort(partId)
+		return "", errBadDigest
+	}
+	etag, err := c.UploadCommitPart(partId, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		c.UploadAbort(partId)
+		return "", uploadError(err, errNoSuchUpload)
+	}
+	return etag, nil
+}
+
+func (h *s3Handler) uploadPart(c client.FileClient, w http.ResponseWriter, r *http.Request, name, id string) {
+	part, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || part < 1 || part > maxParts {
+		writeError(w, r, errInvalidArgument)
+		return
+	}
+	if r.ContentLength < 0 {
+		writeError(w, r, errMissingContentLength)
+		return
+	}
+	if err := loadUpload(c, id, name); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	digest, serr := contentMD5(r)
+	if serr != nil {
+		writeError(w, r, serr)
+		return
+	}
+	etag, serr := writePart(c, id, part, r.Body, r.ContentLength, digest)
+	if serr != nil {
+		writeError(w, r, serr)
+		return
+	}
+	w.Header().Set("Etag", `"`+etag+`"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+// completeMultipartUpload commits the upload as the concatenation of the
+// parts, whose ETags are checked by the File service
+func (h *s3Handler) completeMultipartUpload(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket, key, name, id string) {
+	if err := loadUpload(c, id, name); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	// the body is read entirely so that its signature is checked
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, fileError(err, errMalformedXML))
+		return
+	}
+	req := &completeMultipartUpload{}
+	if err := xml.Unmarshal(b, req); err != nil {
+		writeError(w, r, errMalformedXML)
+		return
+	}
+	if len(req.Parts) == 0 {
+		writeError(w, r, errMalformedXML)
+		return
+	}
+	parts := make([]*proto.UploadPart, len(req.Parts))
+	for i, p := range req.Parts {
+		if i > 0 && p.PartNumber <= req.Parts[i-1].PartNumber {
+			writeError(w, r, errInvalidPartOrder)
+			return
+		}
+		parts[i] = &proto.UploadPart{Part: int32(p.PartNumber), Md5: strings.Trim(p.ETag, `"`)}
+	}
+	if err := c.UploadCommitParts(id, parts); err != nil {
+		if errors.Is(err, client.ErrChecksumMismatch) {
+			writeError(w, r, errInvalidPart)
+			return
+		}
+		writeError(w, r, uploadError(err, errInvalidPart))
+		return
+	}
+	fi, err := client.NewRemoteFs(c).Stat(name)
+	if err != nil {
+		writeError(w, r, fileError(err, errNoSuchKey))
+		return
+	}
+	writeXML(w, http.StatusOK, &completeMultipartUploadResult{
+		Location: path.Join("/", h.opts.prefix, bucket, key),
+		Bucket:   bucket,
+		Key:      key,
+		ETag:     etag(fi),
+	})
+}
+
+func (h *s3Handler) abortMultipartUpload(c client.FileClient, w http.ResponseWriter, r *http.Request, name, id string) {
+	if err := loadUpload(c, id, name); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err := c.UploadAbort(id); err != nil {
+		writeError(w, r, uploadError(err, errNoSuchUpload))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,139 @@
+package s3_handler
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/partitio/go-file/client"
+)
+
+func (h *s3Handler) getObject(c client.FileClient, w http.ResponseWriter, r *http.Request, name string) {
+	f, _, err := c.Open(name)
+	if err != nil {
+		writeError(w, r, fileError(err, errNoSuchKey))
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		writeError(w, r, fileError(err, errNoSuchKey))
+		return
+	}
+	if fi.IsDir() {
+		writeError(w, r, errNoSuchKey)
+		return
+	}
+	w.Header().Set("Etag", etag(fi))
+	http.ServeContent(w, r, path.Base(name), fi.ModTime(), client.NewBlockReader(f, fi.Size()))
+}
+
+func (h *s3Handler) putObject(c client.FileClient, w http.ResponseWriter, r *http.Request, bucket, key, name string) {
+	if r.Header.Get("X-Amz-Copy-Source") != "" {
+		writeError(w, r, errNotImplemented)
+		return
+	}
+	if err := statBucket(c, bucket); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	// the keys ending with a slash are the directories
+	if key[len(key)-1] == '/' {
+		if err := c.MkdirAll(name, 0755); err != nil {
+			writeError(w, r, fileError(err, errNoSuchKey))
+			return
+		}
+		sum := md5.Sum(nil)
+		w.Header().Set("Etag", `"`+hex.EncodeToString(sum[:])+`"`)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	digest, serr := contentMD5(r)
+	if serr != nil {
+		writeError(w, r, serr)
+		return
+	}
+	if _, err := write(c, name, r.Body, digest); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	fi, err := client.NewRemoteFs(c).Stat(name)
+	if err != nil {
+		writeError(w, r, fileError(err, errNoSuchKey))
+		return
+	}
+	w.Header().Set("Etag", etag(fi))
+	w.WriteHeader(http.StatusOK)
+}
+
+// contentMD5 returns the Content-MD5 of r, nil when it is not set
+func contentMD5(r *http.Request) ([]byte, *s3Error) {
+	v := r.Header.Get("Content-MD5")
+	if v == "" {
+		return nil, nil
+	}
+	digest, err := base64.StdEncoding.DecodeString(v)
+	if err != nil || len(digest) != md5.Size {
+		return nil, errInvalidDigest
+	}
+	return digest, nil
+}
+
+// write streams body to the file name, which is only replaced once body is
+// read without errors, such as a mismatch of the signed SHA-256, and matches
+// digest when it is not nil. It returns the MD5 of body.
+func write(c client.FileClient, name string, body io.Reader, digest []byte) ([]byte, *s3Error) {
+	if dir := path.Dir(name); dir != "." {
+		if err := c.MkdirAll(dir, 0755); err != nil {
+			return nil, fileError(err, errNoSuchKey)
+		}
+	}
+	id, err := c.CreateAtomic(name)
+	if err != nil {
+		return nil, fileError(err, errNoSuchKey)
+	}
+	hash := md5.New()
+	body = io.TeeReader(body, hash)
+	b := make([]byte, client.BlockSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(body, b)
+		if n > 0 {
+			if _, err := c.WriteAt(id, offset, b[:n]); err != nil {
+				c.Abort(id)
+				return nil, fileError(err, errNoSuchKey)
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			c.Abort(id)
+			return nil, fileError(err, errNoSuchKey)
+		}
+	}
+	sum := hash.Sum(nil)
+	if digest != nil && !bytes.Equal(digest, sum) {
+		c.Abort(id)
+		return nil, errBadDigest
+	}
+	if err := c.Close(id); err != nil {
+		return nil, fileError(err, errNoSuchKey)
+	}
+	return sum, nil
+}
+
+func (h *s3Handler) deleteObject(c client.FileClient, w http.ResponseWriter, r *http.Request, name string) {
+	// deleting a missing key succeeds
+	if err := c.Remove(name); err != nil && !os.IsNotExist(err) {
+		writeError(w, r, fileError(err, errNoSuchKey))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,41 @@
+package s3_handler
+
+import (
+	"context"
+	"net/http"
+)
+
+type Option func(o *Options)
+
+type HeaderMatcher func(h http.Header) context.Context
+
+type Options struct {
+	headersMatcher HeaderMatcher
+	prefix         string
+	credentials    map[string]string
+}
+
+func WithHeaderMatcher(hm HeaderMatcher) Option {
+	return func(o *Options) {
+		o.headersMatcher = hm
+	}
+}
+
+// WithPrefix strips prefix from the url paths, the rest of the path is
+// /bucket/key
+func WithPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.prefix = prefix
+	}
+}
+
+// WithCredentials adds a key pair accepted in the SigV4 signatures of the
+// requests. Without credentials the requests are not authenticated.
+func WithCredentials(accessKey, secretKey string) Option {
+	return func(o *Options) {
+		if o.credentials == nil {
+			o.credentials = make(map[string]string)
+		}
+		o.credentials[accessKey] = secretKey
+	}
+}
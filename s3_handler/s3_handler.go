@@ -0,0 +1,139 @@
+package s3_handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/partitio/go-file/client"
+)
+
+// s3Handler serves a subset of the S3 API with path style urls, the buckets
+// are the top level directories of the File service and the keys the paths
+// of the files in them
+type s3Handler struct {
+	client client.FileClient
+	opts   *Options
+}
+
+func (h *s3Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logrus.Tracef("s3 request: %s %s", r.Method, r.URL)
+	p := r.URL.Path
+	if !strings.HasPrefix(p, h.opts.prefix) {
+		writeError(w, r, errNoSuchBucket)
+		return
+	}
+	p = strings.TrimPrefix(strings.TrimPrefix(p, h.opts.prefix), "/")
+	if len(h.opts.credentials) > 0 {
+		payload, err := h.authenticate(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if payload != "" {
+			r.Body = &payloadReader{r: r.Body, hash: sha256.New(), expected: payload}
+		}
+	}
+	c := h.client.WithContext(h.opts.headersMatcher(r.Header))
+
+	bucket, key := p, ""
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		bucket, key = p[:i], p[i+1:]
+	}
+	if bucket == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, r, errMethodNotAllowed)
+			return
+		}
+		h.listBuckets(c, w, r)
+		return
+	}
+	if !validBucket(bucket) {
+		writeError(w, r, errInvalidBucketName)
+		return
+	}
+
+	q := r.URL.Query()
+	if key == "" {
+		switch r.Method {
+		case http.MethodGet:
+			if _, ok := q["uploads"]; ok {
+				writeError(w, r, errNotImplemented)
+				return
+			}
+			h.listObjects(c, w, r, bucket)
+		case http.MethodHead:
+			h.headBucket(c, w, r, bucket)
+		case http.MethodPut:
+			h.createBucket(c, w, r, bucket)
+		case http.MethodDelete:
+			h.deleteBucket(c, w, r, bucket)
+		default:
+			writeError(w, r, errMethodNotAllowed)
+		}
+		return
+	}
+
+	name, ok := objectName(bucket, key)
+	if !ok {
+		writeError(w, r, errInvalidKey)
+		return
+	}
+	_, uploads := q["uploads"]
+	uploadId := q.Get("uploadId")
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		h.getObject(c, w, r, name)
+	case r.Method == http.MethodPut && uploadId != "":
+		h.uploadPart(c, w, r, name, uploadId)
+	case r.Method == http.MethodPut:
+		h.putObject(c, w, r, bucket, key, name)
+	case r.Method == http.MethodPost && uploads:
+		h.createMultipartUpload(c, w, r, bucket, key, name)
+	case r.Method == http.MethodPost && uploadId != "":
+		h.completeMultipartUpload(c, w, r, bucket, key, name, uploadId)
+	case r.Method == http.MethodDelete && uploadId != "":
+		h.abortMultipartUpload(c, w, r, name, uploadId)
+	case r.Method == http.MethodDelete:
+		h.deleteObject(c, w, r, name)
+	default:
+		writeError(w, r, errMethodNotAllowed)
+	}
+}
+
+// validBucket reports whether name can be a bucket, the hidden directories
+// are not buckets
+func validBucket(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, "\\\x00")
+}
+
+// objectName returns the name of the file of key, the keys which are not
+// clean paths have no file. The keys ending with a slash are directories.
+func objectName(bucket, key string) (string, bool) {
+	for _, v := range strings.Split(strings.TrimSuffix(key, "/"), "/") {
+		if v == "" || v == "." || v == ".." || strings.ContainsAny(v, "\\\x00") {
+			return "", false
+		}
+	}
+	return bucket + "/" + strings.TrimSuffix(key, "/"), true
+}
+
+// NewS3Handler returns a handler serving the files of the File service
+// through a subset of the S3 API: the bucket and object operations and the
+// multipart uploads. The requests are verified against the credentials
+// given with WithCredentials.
+func NewS3Handler(client client.FileClient, options ...Option) http.Handler {
+	o := &Options{}
+	for _, v := range options {
+		v(o)
+	}
+	if o.headersMatcher == nil {
+		o.headersMatcher = func(h http.Header) context.Context {
+			return context.Background()
+		}
+	}
+	return &s3Handler{client: client, opts: o}
+}
@@ -0,0 +1,29 @@
+package webdav_handler
+
+import (
+	"context"
+	"net/http"
+)
+
+type Option func(o *Options)
+
+type HeaderMatcher func(h http.Header) context.Context
+
+type Options struct {
+	headersMatcher HeaderMatcher
+	prefix         string
+}
+
+func WithHeaderMatcher(hm HeaderMatcher) Option {
+	return func(o *Options) {
+		o.headersMatcher = hm
+	}
+}
+
+// WithPrefix strips prefix from the url paths, the rest of the path is the
+// name of the file relative to the served directory
+func WithPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.prefix = prefix
+	}
+}
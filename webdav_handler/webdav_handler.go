@@ -0,0 +1,95 @@
+package webdav_handler
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/webdav"
+
+	"github.com/partitio/go-file/client"
+)
+
+// fileSystem is a webdav.FileSystem backed by the File service, each call
+// uses the context of its request
+type fileSystem struct {
+	client client.FileClient
+}
+
+func (f *fileSystem) fs(ctx context.Context) *client.RemoteFs {
+	return client.NewRemoteFs(f.client.WithContext(ctx))
+}
+
+func (f *fileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return f.fs(ctx).Mkdir(name, perm)
+}
+
+func (f *fileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	return f.fs(ctx).OpenFile(name, flag, perm)
+}
+
+func (f *fileSystem) RemoveAll(ctx context.Context, name string) error {
+	return f.fs(ctx).RemoveAll(name)
+}
+
+func (f *fileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	return f.fs(ctx).Rename(oldName, newName)
+}
+
+func (f *fileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	return f.fs(ctx).Stat(name)
+}
+
+// requestContext is the context of a request, carrying the values of the
+// context returned by the HeaderMatcher, so that the File service calls are
+// cancelled with the request
+type requestContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c *requestContext) Value(key interface{}) interface{} {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
+
+type webdavHandler struct {
+	handler *webdav.Handler
+	opts    *Options
+}
+
+func (h *webdavHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := &requestContext{Context: r.Context(), values: h.opts.headersMatcher(r.Header)}
+	h.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// NewWebDAVHandler returns a WebDAV handler serving the files of the File
+// service, so that they can be mounted as a network drive. The locks are
+// kept in memory by the handler.
+func NewWebDAVHandler(client client.FileClient, options ...Option) http.Handler {
+	o := &Options{}
+	for _, v := range options {
+		v(o)
+	}
+	if o.headersMatcher == nil {
+		o.headersMatcher = func(h http.Header) context.Context {
+			return context.Background()
+		}
+	}
+	return &webdavHandler{
+		handler: &webdav.Handler{
+			Prefix:     o.prefix,
+			FileSystem: &fileSystem{client: client},
+			LockSystem: webdav.NewMemLS(),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					logrus.Tracef("webdav %s %s: %v", r.Method, r.URL.Path, err)
+				}
+			},
+		},
+		opts: o,
+	}
+}